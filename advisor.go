@@ -0,0 +1,132 @@
+package gormlike
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// IndexWarning describes a LIKE query that can't use an index on its column
+type IndexWarning struct {
+	// Table is the table of the column
+	Table string
+
+	// Column is the column that is matched
+	Column string
+
+	// Index is the name of the index that can't be used
+	Index string
+
+	// Pattern is the pattern the column is matched with
+	Pattern string
+
+	// Reasons are why the index can't be used, e.g. because the pattern starts with a wildcard
+	Reasons []string
+}
+
+// String returns a human-readable description of the warning, e.g.
+// `name LIKE '%x' on users cannot use idx_users_name: the pattern starts with a wildcard`
+func (w IndexWarning) String() string {
+	return fmt.Sprintf("%s LIKE '%s' on %s cannot use %s: %s", w.Column, w.Pattern, w.Table, w.Index, strings.Join(w.Reasons, ", "))
+}
+
+// AdviseIndexes returns a warning for every LIKE query of the query executed on tx that can't use an index defined on
+// its column in the model. Like Explanation(), this requires the `gormlike:explain` setting to be set to true, e.g.
+//
+//	tx := db.Set("gormlike:explain", true).Where(...).Find(&users)
+//	warnings := gormlike.AdviseIndexes(tx)
+//
+// The names select the plugins given to WithName(), like they do for Explanation().
+func AdviseIndexes(tx *gorm.DB, names ...string) []IndexWarning {
+	var result []IndexWarning
+
+	for _, decision := range Explanation(tx, names...) {
+		if !decision.Converted {
+			continue
+		}
+
+		schemaValue := tableSchema(tx, decision.Table)
+		if schemaValue == nil {
+			continue
+		}
+
+		// Associations and JSON paths aren't fields, and elements of arrays are never indexed
+		field := schemaValue.FieldsByDBName[decision.Column]
+		if field == nil || isNativeArrayField(field) || isJSONArrayField(field) {
+			continue
+		}
+
+		for _, index := range schemaValue.ParseIndexes() {
+			// An index can only be used if the column is its first field
+			if len(index.Fields) == 0 || index.Fields[0].Field == nil || index.Fields[0].DBName != field.DBName {
+				continue
+			}
+
+			reasons := indexReasons(decision, index)
+			if len(reasons) == 0 {
+				continue
+			}
+
+			result = append(result, IndexWarning{
+				Table:   decision.Table,
+				Column:  decision.Column,
+				Index:   index.Name,
+				Pattern: decision.Value,
+				Reasons: reasons,
+			})
+		}
+	}
+
+	return result
+}
+
+// indexReasons returns why the LIKE query of the decision can't use the index, nil if it can
+func indexReasons(decision Decision, index *schema.Index) []string {
+	if strings.EqualFold(index.Class, "FULLTEXT") {
+		return []string{"LIKE can't use full-text indexes"}
+	}
+
+	var reasons []string
+
+	condition := strings.ToUpper(decision.SQL)
+	expression := strings.ToUpper(index.Fields[0].Expression)
+
+	// Trigram indexes are GIN or GiST indexes, these support patterns starting with a wildcard
+	trigram := strings.EqualFold(index.Type, "gin") || strings.EqualFold(index.Type, "gist")
+
+	if !trigram && (strings.HasPrefix(decision.Value, "%") || strings.HasPrefix(decision.Value, "_")) {
+		reasons = append(reasons, "the pattern starts with a wildcard")
+	}
+
+	if strings.Contains(condition, "CAST(") && !strings.Contains(expression, "CAST(") {
+		reasons = append(reasons, "the column is wrapped in CAST")
+	}
+
+	if strings.Contains(condition, "LOWER(") && !strings.Contains(expression, "LOWER(") {
+		reasons = append(reasons, "the column is wrapped in LOWER")
+	}
+
+	return reasons
+}
+
+// logIndexAdvice logs the warnings of the index advisor for the statement, if LogIndexAdvice() is used
+func (d *gormLike) logIndexAdvice(db *gorm.DB) {
+	if !d.indexAdvice {
+		return
+	}
+
+	warnings := AdviseIndexes(db, d.name)
+	if len(warnings) == 0 {
+		return
+	}
+
+	if d.logger != nil {
+		d.logger.logIndexWarnings(db.Statement.Context, warnings)
+
+		return
+	}
+
+	gormDecisionLogger{logger: db.Logger}.logIndexWarnings(db.Statement.Context, warnings)
+}
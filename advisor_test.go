@@ -0,0 +1,205 @@
+package gormlike
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type ObjectJ struct {
+	ID       int
+	Name     string `gorm:"index:idx_object_js_name"`
+	City     string `gorm:"index:idx_object_js_city,type:gin"`
+	Code     string `gorm:"index:idx_object_js_code,expression:CAST(code as varchar)"`
+	Street   string `gorm:"index:idx_object_js_address,priority:2"`
+	Country  string `gorm:"index:idx_object_js_address,priority:1"`
+	Bio      string `gorm:"index:idx_object_js_bio,class:FULLTEXT"`
+	Nickname string
+}
+
+func TestAdviseIndexes_ReturnsExpectedWarnings(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter   map[string]any
+		expected []IndexWarning
+	}{
+		"no wildcards": {
+			filter:   map[string]any{"name": "john"},
+			expected: nil,
+		},
+		"prefix": {
+			filter: map[string]any{"name": "jo%"},
+			expected: []IndexWarning{
+				{Table: "object_js", Column: "name", Index: "idx_object_js_name", Pattern: "jo%", Reasons: []string{"the column is wrapped in CAST"}},
+			},
+		},
+		"suffix": {
+			filter: map[string]any{"name": "%hn"},
+			expected: []IndexWarning{
+				{
+					Table: "object_js", Column: "name", Index: "idx_object_js_name", Pattern: "%hn",
+					Reasons: []string{"the pattern starts with a wildcard", "the column is wrapped in CAST"},
+				},
+			},
+		},
+		"single character wildcard at the start": {
+			filter: map[string]any{"name": "_oh%"},
+			expected: []IndexWarning{
+				{
+					Table: "object_js", Column: "name", Index: "idx_object_js_name", Pattern: "_oh%",
+					Reasons: []string{"the pattern starts with a wildcard", "the column is wrapped in CAST"},
+				},
+			},
+		},
+		"trigram index": {
+			filter: map[string]any{"city": "%dam%"},
+			expected: []IndexWarning{
+				{Table: "object_js", Column: "city", Index: "idx_object_js_city", Pattern: "%dam%", Reasons: []string{"the column is wrapped in CAST"}},
+			},
+		},
+		"expression index": {
+			filter:   map[string]any{"code": "AB%"},
+			expected: nil,
+		},
+		"expression index with leading wildcard": {
+			filter: map[string]any{"code": "%AB"},
+			expected: []IndexWarning{
+				{Table: "object_js", Column: "code", Index: "idx_object_js_code", Pattern: "%AB", Reasons: []string{"the pattern starts with a wildcard"}},
+			},
+		},
+		"first column of composite index": {
+			filter: map[string]any{"country": "ne%"},
+			expected: []IndexWarning{
+				{Table: "object_js", Column: "country", Index: "idx_object_js_address", Pattern: "ne%", Reasons: []string{"the column is wrapped in CAST"}},
+			},
+		},
+		"second column of composite index": {
+			filter:   map[string]any{"street": "%straat"},
+			expected: nil,
+		},
+		"full-text index": {
+			filter: map[string]any{"bio": "%go%"},
+			expected: []IndexWarning{
+				{Table: "object_js", Column: "bio", Index: "idx_object_js_bio", Pattern: "%go%", Reasons: []string{"LIKE can't use full-text indexes"}},
+			},
+		},
+		"no index": {
+			filter:   map[string]any{"nickname": "%jo%"},
+			expected: nil,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			require.NoError(t, db.Use(New()))
+
+			var actual []ObjectJ
+
+			tx := db.Session(&gorm.Session{DryRun: true}).Set("gormlike:explain", true).Where(testData.filter).Find(&actual)
+			require.NoError(t, tx.Error)
+
+			// Act
+			result := AdviseIndexes(tx)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestAdviseIndexes_ReturnsNothingWithoutExplain(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t)
+	require.NoError(t, db.Use(New()))
+
+	var actual []ObjectJ
+
+	tx := db.Session(&gorm.Session{DryRun: true}).Where(map[string]any{"name": "%jo"}).Find(&actual)
+	require.NoError(t, tx.Error)
+
+	// Act
+	result := AdviseIndexes(tx)
+
+	// Assert
+	assert.Nil(t, result)
+}
+
+func TestIndexWarning_String_ReturnsExpectedDescription(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	warning := IndexWarning{
+		Table:   "users",
+		Column:  "name",
+		Index:   "idx_users_name",
+		Pattern: "%x",
+		Reasons: []string{"the pattern starts with a wildcard", "the column is wrapped in CAST"},
+	}
+
+	// Act
+	result := warning.String()
+
+	// Assert
+	expected := "name LIKE '%x' on users cannot use idx_users_name: the pattern starts with a wildcard, the column is wrapped in CAST"
+	assert.Equal(t, expected, result)
+}
+
+func TestGormLike_Initialize_LogsIndexAdvice(t *testing.T) {
+	t.Parallel()
+
+	query := func(db *gorm.DB) error {
+		var actual []ObjectJ
+
+		return db.Session(&gorm.Session{DryRun: true}).Where(map[string]any{"name": "%jo", "nickname": "%jo"}).Find(&actual).Error
+	}
+
+	t.Run("gorm logger", func(t *testing.T) {
+		t.Parallel()
+		// Arrange
+		gormLogger := &recordingLogger{Interface: logger.Discard}
+
+		db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+		db.Logger = gormLogger
+		require.NoError(t, db.Use(New(LogIndexAdvice())))
+
+		// Act
+		err := query(db)
+
+		// Assert
+		require.NoError(t, err)
+
+		expected := []string{"gormlike: name LIKE '%jo' on object_js cannot use idx_object_js_name: the pattern starts with a wildcard, the column is wrapped in CAST"}
+		assert.Equal(t, expected, gormLogger.messages)
+	})
+
+	t.Run("slog", func(t *testing.T) {
+		t.Parallel()
+		// Arrange
+		var output bytes.Buffer
+
+		slogLogger := slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+		db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+		require.NoError(t, db.Use(New(LogIndexAdvice(), WithSlogLogger(slogLogger))))
+
+		// Act
+		err := query(db)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, output.String(), "level=WARN")
+		assert.Contains(t, output.String(), "cannot use idx_object_js_name")
+		assert.Contains(t, output.String(), "index=idx_object_js_name")
+		assert.NotContains(t, output.String(), "nickname")
+	})
+}
@@ -0,0 +1,72 @@
+package gormlike
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// isNativeArrayField returns whether the field is a native array column, like text[] in PostgreSQL
+func isNativeArrayField(field *schema.Field) bool {
+	return strings.HasSuffix(string(field.DataType), "[]")
+}
+
+// isJSONArrayField returns whether the field is a slice or array that is stored as JSON
+func isJSONArrayField(field *schema.Field) bool {
+	fieldType := field.FieldType
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.Kind() != reflect.Slice && fieldType.Kind() != reflect.Array {
+		return false
+	}
+
+	// []byte is not a list of values
+	return fieldType.Elem().Kind() != reflect.Uint8 && isJSONField(field)
+}
+
+// arrayElements returns the SQL that turns the elements of an array column into rows and the name of the column
+// that contains the element, returns false if the dialect is not supported
+func arrayElements(db *gorm.DB, field *schema.Field, table string) (string, string, bool) {
+	column := quoteColumn(db, table, field.DBName)
+
+	switch dialect := db.Dialector.Name(); {
+	case dialect == "postgres" && isNativeArrayField(field):
+		return fmt.Sprintf("unnest(%s) AS elements(v)", column), "v", true
+	case dialect == "postgres":
+		return fmt.Sprintf("jsonb_array_elements_text(CAST(%s AS jsonb)) AS elements(v)", column), "v", true
+	case dialect == "mysql" && !isNativeArrayField(field):
+		return fmt.Sprintf("JSON_TABLE(%s, '$[*]' COLUMNS (v TEXT PATH '$')) AS elements", column), "v", true
+	case dialect == "sqlite" && !isNativeArrayField(field):
+		return fmt.Sprintf("json_each(%s)", column), "value", true
+	default:
+		return "", "", false
+	}
+}
+
+// resolveArrayTarget returns a target that matches if any of the elements in the array column matches
+func resolveArrayTarget(db *gorm.DB, field *schema.Field, table string) (target, bool) {
+	elements, element, ok := arrayElements(db, field, table)
+	if !ok {
+		return target{}, false
+	}
+
+	result := target{
+		field: field,
+		condition: func(how comparison) string {
+			if how == compareEqual {
+				return fmt.Sprintf("EXISTS (SELECT 1 FROM %s WHERE %s)", elements, compare(element, how))
+			}
+
+			cast := fmt.Sprintf("CAST(%s as %s)", element, textType(db.Dialector.Name()))
+
+			return fmt.Sprintf("EXISTS (SELECT 1 FROM %s WHERE %s)", elements, compare(cast, how))
+		},
+	}
+
+	return result, true
+}
@@ -0,0 +1,155 @@
+package gormlike
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestGormLike_Initialize_TriggersLikingOnArraysCorrectly(t *testing.T) {
+	t.Parallel()
+
+	type ObjectE struct {
+		ID     int
+		Tags   []string `gorm:"serializer:json"`
+		Labels []string `gorm:"serializer:json" gormlike:"false"`
+	}
+
+	api := ObjectE{ID: 1, Tags: []string{"prod-eu", "api"}, Labels: []string{"team-a"}}
+	web := ObjectE{ID: 2, Tags: []string{"dev-eu", "web"}, Labels: []string{"team-b"}}
+	db := ObjectE{ID: 3, Tags: []string{"prod-us"}, Labels: []string{}}
+
+	tests := map[string]struct {
+		filter   map[string]any
+		options  []Option
+		expected []ObjectE
+	}{
+		"any element matches": {
+			filter:   map[string]any{"tags": "prod-%"},
+			expected: []ObjectE{api, db},
+		},
+		"any element matches suffix": {
+			filter:   map[string]any{"tags": "%-eu"},
+			expected: []ObjectE{api, web},
+		},
+		"no element matches": {
+			filter:   map[string]any{"tags": "test-%"},
+			expected: []ObjectE{},
+		},
+		"multi-value": {
+			filter:   map[string]any{"tags": []string{"%web%", "%us"}},
+			expected: []ObjectE{web, db},
+		},
+		"multi-value with some like values": {
+			filter:   map[string]any{"tags": []string{"api", "dev-%"}},
+			expected: []ObjectE{api, web},
+		},
+		"with custom character": {
+			filter:   map[string]any{"tags": "prod🍌"},
+			options:  []Option{WithCharacter("🍌")},
+			expected: []ObjectE{api, db},
+		},
+		"disabled with tag": {
+			filter:   map[string]any{"labels": "team-%"},
+			expected: []ObjectE{},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			gormDB := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = gormDB.AutoMigrate(&ObjectE{})
+			plugin := New(testData.options...)
+
+			if err := gormDB.CreateInBatches([]ObjectE{api, web, db}, 10).Error; err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+
+			// Act
+			err := gormDB.Use(plugin)
+
+			// Assert
+			require.NoError(t, err)
+
+			var actual []ObjectE
+			err = gormDB.Where(testData.filter).Find(&actual).Error
+			require.NoError(t, err)
+
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
+
+func TestGormLike_Initialize_GeneratesDialectSpecificArrayQueries(t *testing.T) {
+	t.Parallel()
+
+	type ObjectE struct {
+		Tags   []string `gorm:"serializer:json"`
+		Labels []string `gorm:"type:text[]"`
+	}
+
+	tests := map[string]struct {
+		dialect  string
+		column   string
+		expected string
+	}{
+		"postgres native array": {
+			dialect:  "postgres",
+			column:   "labels",
+			expected: "SELECT * FROM `object_es` WHERE EXISTS (SELECT 1 FROM unnest(`object_es`.`labels`) AS elements(v) WHERE CAST(v as varchar) LIKE ?)",
+		},
+		"postgres json array": {
+			dialect:  "postgres",
+			column:   "tags",
+			expected: "SELECT * FROM `object_es` WHERE EXISTS (SELECT 1 FROM jsonb_array_elements_text(CAST(`object_es`.`tags` AS jsonb)) AS elements(v) WHERE CAST(v as varchar) LIKE ?)",
+		},
+		"mysql json array": {
+			dialect:  "mysql",
+			column:   "tags",
+			expected: "SELECT * FROM `object_es` WHERE EXISTS (SELECT 1 FROM JSON_TABLE(`object_es`.`tags`, '$[*]' COLUMNS (v TEXT PATH '$')) AS elements WHERE CAST(v as char) LIKE ?)",
+		},
+		"sqlite json array": {
+			dialect:  "sqlite",
+			column:   "tags",
+			expected: "SELECT * FROM `object_es` WHERE EXISTS (SELECT 1 FROM json_each(`object_es`.`tags`) WHERE CAST(value as varchar) LIKE ?)",
+		},
+		"sqlite native array is not supported": {
+			dialect:  "sqlite",
+			column:   "labels",
+			expected: "SELECT * FROM `object_es` WHERE `object_es`.`labels` = ?",
+		},
+		"unsupported dialect": {
+			dialect:  "sqlserver",
+			column:   "tags",
+			expected: "SELECT * FROM `object_es` WHERE `object_es`.`tags` = ?",
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			db.Dialector = namedDialector{Dialector: db.Dialector, name: testData.dialect}
+
+			// Act
+			err := db.Use(New())
+
+			// Assert
+			require.NoError(t, err)
+
+			var actual []ObjectE
+			query := db.Session(&gorm.Session{DryRun: true}).Where(map[string]any{testData.column: "prod-%"}).Find(&actual)
+			require.NoError(t, query.Error)
+
+			assert.Equal(t, testData.expected, query.Statement.SQL.String())
+			assert.Equal(t, []any{"prod-%"}, query.Statement.Vars)
+		})
+	}
+}
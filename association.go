@@ -0,0 +1,106 @@
+package gormlike
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// associationSubquery returns the SQL before and after a condition on the related table that selects the rows
+// that have a related row matching the condition, returns false if the relationship is not supported
+func associationSubquery(db *gorm.DB, relationship *schema.Relationship, table string) (string, string, bool) {
+	var ownReferences, relatedReferences []*schema.Reference
+
+	related := relationship.FieldSchema.Table
+
+	var polymorphic string
+
+	for _, reference := range relationship.References {
+		switch {
+		case reference.PrimaryValue != "":
+			// Polymorphic relationships store the type of the owner in the related table
+			polymorphicType := quoteColumn(db, related, reference.ForeignKey.DBName)
+			polymorphic = fmt.Sprintf("%s = '%s' AND ", polymorphicType, strings.ReplaceAll(reference.PrimaryValue, "'", "''"))
+		case reference.OwnPrimaryKey:
+			ownReferences = append(ownReferences, reference)
+		default:
+			relatedReferences = append(relatedReferences, reference)
+		}
+	}
+
+	switch relationship.Type {
+	case schema.BelongsTo:
+		// company_id IN (SELECT id FROM companies WHERE ...)
+		if len(relatedReferences) != 1 || len(ownReferences) != 0 {
+			return "", "", false
+		}
+
+		reference := relatedReferences[0]
+		prefix := fmt.Sprintf("%s IN (SELECT %s FROM %s WHERE %s",
+			quoteColumn(db, table, reference.ForeignKey.DBName), quoteColumn(db, related, reference.PrimaryKey.DBName), db.Statement.Quote(related), polymorphic)
+
+		return prefix, ")", true
+	case schema.HasOne, schema.HasMany:
+		// id IN (SELECT employee_id FROM orders WHERE ...)
+		if len(ownReferences) != 1 || len(relatedReferences) != 0 {
+			return "", "", false
+		}
+
+		reference := ownReferences[0]
+		prefix := fmt.Sprintf("%s IN (SELECT %s FROM %s WHERE %s",
+			quoteColumn(db, table, reference.PrimaryKey.DBName), quoteColumn(db, related, reference.ForeignKey.DBName), db.Statement.Quote(related), polymorphic)
+
+		return prefix, ")", true
+	case schema.Many2Many:
+		// id IN (SELECT employee_id FROM employee_skills WHERE skill_id IN (SELECT id FROM skills WHERE ...))
+		if len(ownReferences) != 1 || len(relatedReferences) != 1 || polymorphic != "" {
+			return "", "", false
+		}
+
+		own, other := ownReferences[0], relatedReferences[0]
+		joinTable := relationship.JoinTable.Table
+		prefix := fmt.Sprintf("%s IN (SELECT %s FROM %s WHERE %s IN (SELECT %s FROM %s WHERE ",
+			quoteColumn(db, table, own.PrimaryKey.DBName), quoteColumn(db, joinTable, own.ForeignKey.DBName), db.Statement.Quote(joinTable),
+			quoteColumn(db, joinTable, other.ForeignKey.DBName), quoteColumn(db, related, other.PrimaryKey.DBName), db.Statement.Quote(related))
+
+		return prefix, "))", true
+	default:
+		return "", "", false
+	}
+}
+
+// resolveAssociationTarget turns a column like `Company.Name` into a target on the `name` column of the related
+// schema, the tags of the related field decide whether it is likeable
+func resolveAssociationTarget(db *gorm.DB, relationship *schema.Relationship, table, name string) (target, bool) {
+	prefix, suffix, ok := associationSubquery(db, relationship, table)
+	if !ok {
+		return target{}, false
+	}
+
+	related := relationship.FieldSchema
+
+	var relatedTarget target
+
+	if relatedField := related.LookUpField(name); relatedField != nil {
+		relatedTarget, ok = resolveFieldTarget(db, related, relatedField, related.Table, relatedField.DBName)
+	} else {
+		relatedTarget, ok = resolveFieldTarget(db, related, nil, related.Table, name)
+	}
+
+	// Fields that don't exist in the related schema can't be queried
+	if !ok || relatedTarget.field == nil {
+		return target{}, false
+	}
+
+	result := target{
+		nested: true,
+		field:  relatedTarget.field,
+		condition: func(how comparison) string {
+			return prefix + relatedTarget.condition(how) + suffix
+		},
+	}
+
+	return result, true
+}
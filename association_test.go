@@ -0,0 +1,272 @@
+package gormlike
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type Company struct {
+	ID     int
+	Name   string
+	Secret string `gormlike:"false"`
+}
+
+type Address struct {
+	ID         int
+	EmployeeID int
+	City       string
+}
+
+type Order struct {
+	ID         int
+	EmployeeID int
+	Reference  string
+}
+
+type Skill struct {
+	ID   int
+	Name string
+}
+
+type Comment struct {
+	ID        int
+	OwnerID   int
+	OwnerType string
+	Text      string
+}
+
+type Employee struct {
+	ID        int
+	Name      string
+	CompanyID int
+	Company   *Company
+	Address   *Address
+	Orders    []Order
+	Skills    []Skill   `gorm:"many2many:employee_skills"`
+	Comments  []Comment `gorm:"polymorphic:Owner"`
+}
+
+func TestGormLike_Initialize_TriggersLikingOnAssociationsCorrectly(t *testing.T) {
+	t.Parallel()
+
+	acme := &Company{ID: 1, Name: "Acme BV", Secret: "abc"}
+	initech := &Company{ID: 2, Name: "Initech", Secret: "def"}
+
+	golang := Skill{ID: 1, Name: "Go"}
+	rust := Skill{ID: 2, Name: "Rust"}
+
+	existing := []Employee{
+		{
+			ID: 1, Name: "John", Company: acme,
+			Address:  &Address{ID: 1, City: "Amsterdam"},
+			Orders:   []Order{{ID: 1, Reference: "INV-1"}, {ID: 2, Reference: "QUO-1"}},
+			Skills:   []Skill{golang},
+			Comments: []Comment{{ID: 1, Text: "great"}},
+		},
+		{
+			ID: 2, Name: "Jane", Company: initech,
+			Address: &Address{ID: 2, City: "Rotterdam"},
+			Orders:  []Order{{ID: 3, Reference: "QUO-2"}},
+			Skills:  []Skill{golang, rust},
+		},
+		{
+			ID: 3, Name: "Amy", Company: acme,
+			Address:  &Address{ID: 3, City: "Utrecht"},
+			Comments: []Comment{{ID: 2, Text: "good"}},
+		},
+	}
+
+	tests := map[string]struct {
+		filter   map[string]any
+		options  []Option
+		expected []int
+	}{
+		"belongs to": {
+			filter:   map[string]any{"Company.Name": "%BV"},
+			expected: []int{1, 3},
+		},
+		"belongs to with db name": {
+			filter:   map[string]any{"Company.name": "Init%"},
+			expected: []int{2},
+		},
+		"belongs to with multiple values": {
+			filter:   map[string]any{"Company.Name": []string{"Initech", "%BV"}},
+			expected: []int{1, 2, 3},
+		},
+		"belongs to combined with other filters": {
+			filter:   map[string]any{"Company.Name": "%BV", "name": "Amy"},
+			expected: []int{3},
+		},
+		"belongs to with exact value": {
+			filter:   map[string]any{"Company.Name": "Acme BV"},
+			expected: []int{1, 3},
+		},
+		"belongs to with exact values": {
+			filter:   map[string]any{"Company.Name": []string{"Initech", "Acme BV"}},
+			expected: []int{1, 2, 3},
+		},
+		"belongs to with exact value on a field that isn't likeable": {
+			filter:   map[string]any{"Company.Secret": "def"},
+			expected: []int{2},
+		},
+		"has one": {
+			filter:   map[string]any{"Address.City": "%dam"},
+			expected: []int{1, 2},
+		},
+		"has many": {
+			filter:   map[string]any{"Orders.Reference": "INV%"},
+			expected: []int{1},
+		},
+		"has many with exact number": {
+			filter:   map[string]any{"Orders.ID": 3},
+			expected: []int{2},
+		},
+		"has many with multiple values": {
+			filter:   map[string]any{"Orders.Reference": []string{"INV%", "QUO-2"}},
+			expected: []int{1, 2},
+		},
+		"many to many": {
+			filter:   map[string]any{"Skills.Name": "R%"},
+			expected: []int{2},
+		},
+		"polymorphic": {
+			filter:   map[string]any{"Comments.Text": "g%"},
+			expected: []int{1, 3},
+		},
+		"with custom character": {
+			filter:   map[string]any{"Company.Name": "🍌BV"},
+			options:  []Option{WithCharacter("🍌")},
+			expected: []int{1, 3},
+		},
+		"tokenised": {
+			filter:   map[string]any{"Company.Name": "bv ac"},
+			options:  []Option{Tokenised(), WithTokenColumns("Company.Name")},
+			expected: []int{1, 3},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&Company{}, &Address{}, &Order{}, &Skill{}, &Comment{}, &Employee{})
+			plugin := New(testData.options...)
+
+			if err := db.CreateInBatches(existing, 10).Error; err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+
+			// Act
+			err := db.Use(plugin)
+
+			// Assert
+			require.NoError(t, err)
+
+			var actual []int
+			err = db.Model(&Employee{}).Where(testData.filter).Order("id").Pluck("id", &actual).Error
+			require.NoError(t, err)
+
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
+
+func TestGormLike_Initialize_IgnoresUnlikeableAssociationFields(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter  map[string]any
+		options []Option
+	}{
+		"disabled with tag": {
+			filter: map[string]any{"Company.Secret": "%b%"},
+		},
+		"not tagged": {
+			filter:  map[string]any{"Company.Name": "%BV"},
+			options: []Option{TaggedOnly()},
+		},
+		"unknown field": {
+			filter: map[string]any{"Company.Unknown": "%BV"},
+		},
+		"unknown relation": {
+			filter: map[string]any{"Unknown.Name": "%BV"},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+			// Act
+			err := db.Use(New(testData.options...))
+
+			// Assert
+			require.NoError(t, err)
+
+			var actual []Employee
+			query := db.Session(&gorm.Session{DryRun: true}).Where(testData.filter).Find(&actual)
+			require.NoError(t, query.Error)
+
+			assert.NotContains(t, query.Statement.SQL.String(), "LIKE")
+		})
+	}
+}
+
+func TestGormLike_Initialize_GeneratesExpectedAssociationQueries(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		column   string
+		expected string
+	}{
+		"belongs to": {
+			column:   "Company.Name",
+			expected: "SELECT * FROM `employees` WHERE `employees`.`company_id` IN (SELECT `companies`.`id` FROM `companies` WHERE CAST(`companies`.`name` as varchar) LIKE ?)",
+		},
+		"has one": {
+			column:   "Address.City",
+			expected: "SELECT * FROM `employees` WHERE `employees`.`id` IN (SELECT `addresses`.`employee_id` FROM `addresses` WHERE CAST(`addresses`.`city` as varchar) LIKE ?)",
+		},
+		"has many": {
+			column:   "Orders.Reference",
+			expected: "SELECT * FROM `employees` WHERE `employees`.`id` IN (SELECT `orders`.`employee_id` FROM `orders` WHERE CAST(`orders`.`reference` as varchar) LIKE ?)",
+		},
+		"many to many": {
+			column:   "Skills.Name",
+			expected: "SELECT * FROM `employees` WHERE `employees`.`id` IN (SELECT `employee_skills`.`employee_id` FROM `employee_skills` WHERE `employee_skills`.`skill_id` IN (SELECT `skills`.`id` FROM `skills` WHERE CAST(`skills`.`name` as varchar) LIKE ?))",
+		},
+		"polymorphic": {
+			column:   "Comments.Text",
+			expected: "SELECT * FROM `employees` WHERE `employees`.`id` IN (SELECT `comments`.`owner_id` FROM `comments` WHERE `comments`.`owner_type` = 'employees' AND CAST(`comments`.`text` as varchar) LIKE ?)",
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+			// Act
+			err := db.Use(New())
+
+			// Assert
+			require.NoError(t, err)
+
+			var actual []Employee
+			query := db.Session(&gorm.Session{DryRun: true}).Where(map[string]any{testData.column: "a%"}).Find(&actual)
+			require.NoError(t, query.Error)
+
+			assert.Equal(t, testData.expected, query.Statement.SQL.String())
+			assert.Equal(t, []any{"a%"}, query.Statement.Vars)
+		})
+	}
+}
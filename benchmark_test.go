@@ -0,0 +1,150 @@
+package gormlike
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ObjectBench has 20 columns, half of which are explicitly tagged
+type ObjectBench struct {
+	ID      int
+	Field1  string `gormlike:"true"`
+	Field2  string
+	Field3  string `gormlike:"true;match:prefix"`
+	Field4  string
+	Field5  string `gormlike:"true"`
+	Field6  string
+	Field7  string `gormlike:"true"`
+	Field8  string
+	Field9  string `gormlike:"true"`
+	Field10 string
+	Field11 string `gormlike:"true"`
+	Field12 string
+	Field13 string `gormlike:"true"`
+	Field14 string
+	Field15 string `gormlike:"true"`
+	Field16 string
+	Field17 string `gormlike:"true"`
+	Field18 string
+	Field19 string `gormlike:"true"`
+	Field20 string `gormlike:"false"`
+}
+
+// benchmarkExpressions returns a filter of 20 conditions, most of which contain a wildcard
+func benchmarkExpressions() []clause.Expression {
+	expressions := make([]clause.Expression, 0, 20)
+
+	for index := 1; index <= 20; index++ {
+		value := fmt.Sprintf("value%d%%", index)
+		if index%5 == 0 {
+			value = fmt.Sprintf("value%d", index)
+		}
+
+		expressions = append(expressions, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: fmt.Sprintf("field%d", index)}, Value: value})
+	}
+
+	return expressions
+}
+
+// benchmarkInExpressions returns a filter of 20 IN conditions, each with 2 patterns and a plain value
+func benchmarkInExpressions() []clause.Expression {
+	expressions := make([]clause.Expression, 0, 20)
+
+	for index := 1; index <= 20; index++ {
+		values := []any{fmt.Sprintf("value%d%%", index), fmt.Sprintf("%%value%d", index), fmt.Sprintf("value%d", index)}
+
+		expressions = append(expressions, clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: fmt.Sprintf("field%d", index)}, Values: values})
+	}
+
+	return expressions
+}
+
+// newMemoryDatabase returns an in-memory database for benchmarks and fuzz tests, gormtestutil only accepts a *testing.T
+func newMemoryDatabase(tb testing.TB) *gorm.DB {
+	tb.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(tb, err)
+
+	return db
+}
+
+func BenchmarkRewrite_20Conditions(b *testing.B) {
+	db := newMemoryDatabase(b)
+
+	statement := &gorm.Statement{DB: db, Model: &ObjectBench{}}
+	require.NoError(b, statement.Parse(statement.Model))
+
+	expressions := benchmarkExpressions()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := Rewrite(statement, expressions); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGormLike_Query_20Conditions(b *testing.B) {
+	db := newMemoryDatabase(b)
+	require.NoError(b, db.Use(New()))
+
+	session := db.Session(&gorm.Session{DryRun: true})
+	expressions := benchmarkExpressions()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		var result []ObjectBench
+		if err := session.Clauses(clause.Where{Exprs: expressions}).Find(&result).Error; err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRewrite_20InConditions(b *testing.B) {
+	db := newMemoryDatabase(b)
+
+	statement := &gorm.Statement{DB: db, Model: &ObjectBench{}}
+	require.NoError(b, statement.Parse(statement.Model))
+
+	expressions := benchmarkInExpressions()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := Rewrite(statement, expressions); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRewrite_Tokenised(b *testing.B) {
+	db := newMemoryDatabase(b)
+
+	statement := &gorm.Statement{DB: db, Model: &ObjectBench{}}
+	require.NoError(b, statement.Parse(statement.Model))
+
+	expressions := []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "field1"}, Value: `john "new york" amsterdam`},
+	}
+	options := []Option{Tokenised(), WithTokenColumns("field1", "field5", "field7")}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := Rewrite(statement, expressions, options...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
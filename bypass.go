@@ -0,0 +1,59 @@
+package gormlike
+
+import (
+	"gorm.io/gorm"
+)
+
+// bypassKey is the setting that turns off the plugin, it can't be given to db.Set so nothing can turn it on again
+type bypassKey struct{}
+
+// Uninstall removes the callbacks of the plugin from the database, after which it can be registered again using
+// db.Use. Without names it removes the plugin named gormlike, otherwise the plugins with the names given to WithName().
+// Like db.Use, this is not safe to call while queries are running.
+func Uninstall(db *gorm.DB, names ...string) error {
+	if len(names) == 0 {
+		names = []string{tagName}
+	}
+
+	for _, name := range names {
+		processors := []interface {
+			Get(name string) func(*gorm.DB)
+			Remove(name string) error
+		}{db.Callback().Query(), db.Callback().Row()}
+
+		for _, processor := range processors {
+			for _, callback := range []string{name + ":query", name + ":row", name + ":observe_query", name + ":observe_row"} {
+				// Removing a callback that doesn't exist results in a warning from gorm
+				if processor.Get(callback) == nil {
+					continue
+				}
+
+				if err := processor.Remove(callback); err != nil {
+					return err
+				}
+			}
+		}
+
+		delete(db.Config.Plugins, name)
+	}
+
+	return nil
+}
+
+// Bypass returns a session in which none of the plugins convert queries, regardless of later calls like
+// db.Set("gormlike", true). This is useful for migrations and data repairs, where a % should be taken literally.
+// Preloads of the session are bypassed as well, subqueries built from another session are not.
+func Bypass(db *gorm.DB) *gorm.DB {
+	// Clauses() returns a new statement, which the setting is added to as the key can't be given to Set
+	tx := db.Clauses()
+	tx.Statement.Settings.Store(bypassKey{}, true)
+
+	return tx.Session(&gorm.Session{})
+}
+
+// bypassed returns whether the statement was created using Bypass
+func bypassed(db *gorm.DB) bool {
+	_, ok := db.Statement.Settings.Load(bypassKey{})
+
+	return ok
+}
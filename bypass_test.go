@@ -0,0 +1,154 @@
+package gormlike
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type ObjectT struct {
+	ID   int
+	Name string
+}
+
+func TestUninstall_RemovesCallbacks(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options []Option
+		names   []string
+
+		callback string
+	}{
+		"default name": {
+			callback: "gormlike",
+		},
+		"with observer": {
+			options:  []Option{WithObserver(&MemoryObserver{})},
+			callback: "gormlike",
+		},
+		"named": {
+			options:  []Option{WithName("search")},
+			names:    []string{"search"},
+			callback: "search",
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			require.NoError(t, db.Use(New(testData.options...)))
+
+			// Act
+			err := Uninstall(db, testData.names...)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Nil(t, db.Callback().Query().Get(testData.callback+":query"))
+			assert.Nil(t, db.Callback().Row().Get(testData.callback+":row"))
+			assert.Nil(t, db.Callback().Query().Get(testData.callback+":observe_query"))
+			assert.Nil(t, db.Callback().Row().Get(testData.callback+":observe_row"))
+			assert.NotContains(t, db.Config.Plugins, testData.callback)
+			assert.NotNil(t, db.Callback().Query().Get("gorm:query"))
+		})
+	}
+}
+
+func TestUninstall_StopsConvertingQueries(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	require.NoError(t, db.Use(New()))
+
+	// Act
+	err := Uninstall(db)
+
+	// Assert
+	require.NoError(t, err)
+
+	query := db.Session(&gorm.Session{DryRun: true}).Where(map[string]any{"name": "j%"}).Find(&[]ObjectT{})
+	require.NoError(t, query.Error)
+	assert.Equal(t, "SELECT * FROM `object_ts` WHERE `object_ts`.`name` = ?", query.Statement.SQL.String())
+
+	// It can be registered again
+	require.NoError(t, db.Use(New()))
+
+	query = db.Session(&gorm.Session{DryRun: true}).Where(map[string]any{"name": "j%"}).Find(&[]ObjectT{})
+	require.NoError(t, query.Error)
+	assert.Equal(t, "SELECT * FROM `object_ts` WHERE CAST(`object_ts`.`name` as varchar) LIKE ?", query.Statement.SQL.String())
+}
+
+func TestBypass_TurnsOffThePlugin(t *testing.T) {
+	t.Parallel()
+
+	enable := func(db *gorm.DB) *gorm.DB {
+		return db.Set("gormlike", true)
+	}
+
+	tests := map[string]struct {
+		plugin gorm.Plugin
+		query  func(*gorm.DB) *gorm.DB
+	}{
+		"default": {
+			plugin: New(),
+			query:  func(db *gorm.DB) *gorm.DB { return db },
+		},
+		"setting enabled afterwards": {
+			plugin: New(SettingOnly()),
+			query:  enable,
+		},
+		"scope enabling the setting": {
+			plugin: New(SettingOnly()),
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Scopes(enable)
+			},
+		},
+		"named instance": {
+			plugin: New(WithName("search"), SettingOnly()),
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Set("search", true)
+			},
+		},
+		"controller": {
+			plugin: NewController(),
+			query:  func(db *gorm.DB) *gorm.DB { return db },
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectT{})
+
+			existing := []ObjectT{{ID: 1, Name: "jessica"}, {ID: 2, Name: "j%"}}
+			require.NoError(t, db.Create(&existing).Error)
+
+			require.NoError(t, db.Use(testData.plugin))
+
+			// Act
+			bypassed := Bypass(db)
+
+			// Assert
+			var actual []ObjectT
+			require.NoError(t, testData.query(bypassed).Where(map[string]any{"name": "j%"}).Find(&actual).Error)
+			assert.Equal(t, []ObjectT{existing[1]}, actual)
+
+			// The session can be used multiple times
+			actual = nil
+			require.NoError(t, testData.query(bypassed).Where(map[string]any{"name": "j%"}).Find(&actual).Error)
+			assert.Equal(t, []ObjectT{existing[1]}, actual)
+
+			// The original database is left alone
+			actual = nil
+			require.NoError(t, testData.query(db).Where(map[string]any{"name": "j%"}).Order("id").Find(&actual).Error)
+			assert.Equal(t, existing, actual)
+		})
+	}
+}
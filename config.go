@@ -0,0 +1,170 @@
+package gormlike
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+	"weak"
+
+	"gorm.io/gorm/schema"
+)
+
+// ModelConfig configures which columns of a model are likeable and how, as an alternative to `gormlike` tags.
+// Return it from a LikeConfig() method on the model, e.g.
+//
+//	func (User) LikeConfig() gormlike.ModelConfig {
+//		return gormlike.ModelConfig{Columns: map[string]gormlike.ColumnConfig{"name": {CaseInsensitive: true}}}
+//	}
+type ModelConfig struct {
+	// Columns are the configured columns by their database name, these are likeable as if they were tagged with
+	// `gormlike:"true"`, unless they are disabled. Tags on the fields take precedence.
+	Columns map[string]ColumnConfig
+}
+
+// ColumnConfig configures a single column of a ModelConfig
+type ColumnConfig struct {
+	// Disabled makes the column not likeable, as if it was tagged with `gormlike:"false"`
+	Disabled bool
+
+	// Match only allows patterns of this kind, e.g. PatternPrefix. All kinds are allowed if empty.
+	Match PatternKind
+
+	// CaseInsensitive matches the column regardless of case, using LOWER(column) LIKE LOWER(?)
+	CaseInsensitive bool
+}
+
+// LikeConfigurer is implemented by models that configure their likeable columns using a ModelConfig
+type LikeConfigurer interface {
+	LikeConfig() ModelConfig
+}
+
+// modelConfig returns the ModelConfig of the model of the schema, false if it doesn't have one
+func modelConfig(schemaValue *schema.Schema) (*ModelConfig, bool) {
+	if schemaValue == nil || schemaValue.ModelType == nil {
+		return nil, false
+	}
+
+	// A pointer covers LikeConfig() methods on both the value and the pointer
+	configurer, ok := reflect.New(schemaValue.ModelType).Interface().(LikeConfigurer)
+	if !ok {
+		return nil, false
+	}
+
+	config := configurer.LikeConfig()
+
+	return &config, true
+}
+
+// fieldPolicy is what may be done with a field, based on its `gormlike` tag and the ModelConfig of its model
+type fieldPolicy struct {
+	// value is either true, false or empty, like the value of the tag
+	value string
+
+	// match is the only kind of pattern that is allowed, all kinds are allowed if empty
+	match PatternKind
+
+	// caseInsensitive matches the field regardless of case
+	caseInsensitive bool
+}
+
+// fieldPolicies caches the policies of the fields of every schema, as parsing tags and calling LikeConfig() on every
+// condition of every query adds up. They are populated the first time a field of the schema is used. The fields are
+// weak keys, so the policies are removed once GORM drops the schema, e.g. when the database is closed.
+var fieldPolicies sync.Map
+
+// policyOf returns the policy of the field, an empty policy if the field is unknown
+func policyOf(field *schema.Field) fieldPolicy {
+	if field == nil {
+		return fieldPolicy{}
+	}
+
+	if field.Schema == nil {
+		return newFieldPolicy(field, nil)
+	}
+
+	if cached, ok := fieldPolicies.Load(weak.Make(field)); ok {
+		policy, _ := cached.(fieldPolicy)
+
+		return policy
+	}
+
+	config, _ := modelConfig(field.Schema)
+	cacheSchemaPolicies(field.Schema, config)
+
+	return newFieldPolicy(field, config)
+}
+
+// cacheSchemaPolicies stores the policies of all fields of the schema, which are removed once a field is garbage
+// collected. The policies don't point to the schema, otherwise the cache would keep it alive.
+func cacheSchemaPolicies(schemaValue *schema.Schema, config *ModelConfig) {
+	for _, field := range schemaValue.Fields {
+		key := weak.Make(field)
+
+		if _, loaded := fieldPolicies.LoadOrStore(key, newFieldPolicy(field, config)); !loaded {
+			runtime.AddCleanup(field, func(key weak.Pointer[schema.Field]) { fieldPolicies.Delete(key) }, key)
+		}
+	}
+}
+
+// newFieldPolicy returns the policy of the field based on its tag and the ModelConfig of its model, if any
+func newFieldPolicy(field *schema.Field, config *ModelConfig) fieldPolicy {
+	tag := parseTag(field.Tag.Get(tagName))
+	result := fieldPolicy{value: tag.value, match: PatternKind(tag.match)}
+
+	if config == nil {
+		return result
+	}
+
+	column, ok := config.Columns[field.DBName]
+	if !ok {
+		return result
+	}
+
+	if result.value == "" {
+		result.value = "true"
+
+		if column.Disabled {
+			result.value = "false"
+		}
+	}
+
+	if result.match == "" {
+		result.match = column.Match
+	}
+
+	result.caseInsensitive = column.CaseInsensitive
+
+	return result
+}
+
+// allows returns whether all patterns are allowed by the policy
+func (p fieldPolicy) allows(patterns ...string) bool {
+	if p.match == "" {
+		return true
+	}
+
+	for _, pattern := range patterns {
+		if patternKind(pattern) != p.match {
+			return false
+		}
+	}
+
+	return true
+}
+
+// comparison returns how the field is compared with patterns
+func (p fieldPolicy) comparison() comparison {
+	if p.caseInsensitive {
+		return compareLikeLower
+	}
+
+	return compareLike
+}
+
+// Searchable returns whether LIKE queries on the field are allowed by its tag or the LikeConfig of its model.
+// taggedOnly requires the field to be marked as likeable explicitly, like TaggedOnly() does.
+func Searchable(field *schema.Field, taggedOnly bool) bool {
+	plugin := gormLike{conditionalTag: taggedOnly}
+
+	return plugin.isLikeable(policyOf(field).value)
+}
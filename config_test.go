@@ -0,0 +1,347 @@
+package gormlike
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"weak"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+type ObjectM struct {
+	ID       int
+	Name     string
+	Code     string
+	Secret   string
+	Nickname string `gormlike:"false"`
+	Other    string
+}
+
+func (ObjectM) LikeConfig() ModelConfig {
+	return ModelConfig{
+		Columns: map[string]ColumnConfig{
+			"name":     {CaseInsensitive: true},
+			"code":     {Match: PatternPrefix},
+			"secret":   {Disabled: true},
+			"nickname": {},
+		},
+	}
+}
+
+func TestGormLike_Initialize_UsesModelConfig(t *testing.T) {
+	t.Parallel()
+
+	jessica := ObjectM{ID: 1, Name: "jessica", Code: "AB-1", Secret: "abc", Nickname: "jess", Other: "def"}
+	amy := ObjectM{ID: 2, Name: "amy", Code: "CD-AB", Secret: "ghi", Nickname: "aims", Other: "jkl"}
+
+	tests := map[string]struct {
+		filter   map[string]any
+		options  []Option
+		expected []ObjectM
+	}{
+		"configured column": {
+			filter:   map[string]any{"name": "%ss%"},
+			expected: []ObjectM{jessica},
+		},
+		"prefix on prefix column": {
+			filter:   map[string]any{"code": "AB%"},
+			expected: []ObjectM{jessica},
+		},
+		"suffix on prefix column": {
+			filter:   map[string]any{"code": "%AB"},
+			expected: []ObjectM{},
+		},
+		"prefix and suffix in list on prefix column": {
+			filter:   map[string]any{"code": []string{"AB%", "%AB"}},
+			expected: []ObjectM{},
+		},
+		"tokens on prefix column": {
+			filter:   map[string]any{"code": "AB"},
+			options:  []Option{Tokenised()},
+			expected: []ObjectM{},
+		},
+		"disabled column": {
+			filter:   map[string]any{"secret": "%b%"},
+			expected: []ObjectM{},
+		},
+		"tag takes precedence": {
+			filter:   map[string]any{"nickname": "%s%"},
+			expected: []ObjectM{},
+		},
+		"unconfigured column": {
+			filter:   map[string]any{"other": "%e%"},
+			expected: []ObjectM{jessica},
+		},
+		"configured column in tagged only mode": {
+			filter:   map[string]any{"name": "%m%"},
+			options:  []Option{TaggedOnly()},
+			expected: []ObjectM{amy},
+		},
+		"unconfigured column in tagged only mode": {
+			filter:   map[string]any{"other": "%e%"},
+			options:  []Option{TaggedOnly()},
+			expected: []ObjectM{},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectM{})
+			require.NoError(t, db.Create([]ObjectM{jessica, amy}).Error)
+			require.NoError(t, db.Use(New(testData.options...)))
+
+			var actual []ObjectM
+
+			// Act
+			err := db.Where(testData.filter).Find(&actual).Error
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
+
+func TestGormLike_Initialize_GeneratesCaseInsensitiveQueries(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t)
+	require.NoError(t, db.Use(New()))
+
+	var actual []ObjectM
+
+	// Act
+	query := db.Session(&gorm.Session{DryRun: true}).Where(map[string]any{"name": "Jes%"}).Find(&actual)
+
+	// Assert
+	require.NoError(t, query.Error)
+	assert.Equal(t, "SELECT * FROM `object_ms` WHERE LOWER(CAST(`object_ms`.`name` as varchar)) LIKE LOWER(?)", query.Statement.SQL.String())
+}
+
+func TestGormLike_Initialize_ReturnsErrorOnPatternNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	type ObjectN struct {
+		Code string `gormlike:"true;match:prefix"`
+	}
+
+	tests := map[string]struct {
+		filter map[string]any
+		err    error
+	}{
+		"prefix": {
+			filter: map[string]any{"code": "AB%"},
+		},
+		"suffix": {
+			filter: map[string]any{"code": "%AB"},
+			err:    ErrPatternNotAllowed,
+		},
+		"contains": {
+			filter: map[string]any{"code": "%AB%"},
+			err:    ErrPatternNotAllowed,
+		},
+		"suffix in list": {
+			filter: map[string]any{"code": []string{"AB%", "%AB"}},
+			err:    ErrPatternNotAllowed,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectN{})
+			require.NoError(t, db.Use(New(Strict())))
+
+			var actual []ObjectN
+
+			// Act
+			err := db.Where(testData.filter).Find(&actual).Error
+
+			// Assert
+			if testData.err != nil {
+				require.ErrorIs(t, err, testData.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+// configCalls counts the calls to ObjectO.LikeConfig
+var configCalls atomic.Int32
+
+type ObjectO struct {
+	Name string
+}
+
+func (*ObjectO) LikeConfig() ModelConfig {
+	configCalls.Add(1)
+
+	return ModelConfig{Columns: map[string]ColumnConfig{"name": {}}}
+}
+
+func TestModelConfig_IsCachedPerSchema(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t)
+	_ = db.AutoMigrate(&ObjectO{})
+	require.NoError(t, db.Use(New(TaggedOnly())))
+
+	// The counter is shared with earlier runs of this test, e.g. using -count
+	before := configCalls.Load()
+
+	// Act
+	for range 3 {
+		var actual []ObjectO
+		require.NoError(t, db.Where(map[string]any{"name": "%a%"}).Find(&actual).Error)
+	}
+
+	// Assert
+	assert.Equal(t, int32(1), configCalls.Load()-before)
+}
+
+func TestPolicyOf_ForgetsSchemasThatAreDropped(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	type ObjectO struct {
+		Name string `gormlike:"true"`
+	}
+
+	parsed, err := schema.Parse(&ObjectO{}, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+
+	field := parsed.FieldsByDBName["name"]
+	key := weak.Make(field)
+
+	// Act
+	policy := policyOf(field)
+
+	// Assert
+	assert.Equal(t, "true", policy.value)
+
+	_, cached := fieldPolicies.Load(key)
+	assert.True(t, cached)
+
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+
+		_, cached := fieldPolicies.Load(key)
+
+		return !cached
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestModelConfig_ReturnsNothingForModelsWithoutConfig(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	statement := &gorm.Statement{DB: gormtestutil.NewMemoryDatabase(t)}
+	require.NoError(t, statement.Parse(&ObjectL{}))
+
+	// Act
+	config, ok := modelConfig(statement.Schema)
+
+	// Assert
+	assert.False(t, ok)
+	assert.Nil(t, config)
+
+	_, ok = modelConfig(&schema.Schema{})
+	assert.False(t, ok)
+}
+
+func TestPolicyOf_ReturnsExpectedPolicy(t *testing.T) {
+	t.Parallel()
+
+	statement := &gorm.Statement{DB: gormtestutil.NewMemoryDatabase(t)}
+	require.NoError(t, statement.Parse(&ObjectM{}))
+
+	tests := map[string]struct {
+		field    *schema.Field
+		expected fieldPolicy
+	}{
+		"unknown field": {
+			field:    nil,
+			expected: fieldPolicy{},
+		},
+		"field without schema": {
+			field:    &schema.Field{Tag: `gormlike:"true;match:prefix"`},
+			expected: fieldPolicy{value: "true", match: PatternPrefix},
+		},
+		"case insensitive": {
+			field:    statement.Schema.FieldsByDBName["name"],
+			expected: fieldPolicy{value: "true", caseInsensitive: true},
+		},
+		"prefix": {
+			field:    statement.Schema.FieldsByDBName["code"],
+			expected: fieldPolicy{value: "true", match: PatternPrefix},
+		},
+		"disabled": {
+			field:    statement.Schema.FieldsByDBName["secret"],
+			expected: fieldPolicy{value: "false"},
+		},
+		"tag over config": {
+			field:    statement.Schema.FieldsByDBName["nickname"],
+			expected: fieldPolicy{value: "false"},
+		},
+		"not configured": {
+			field:    statement.Schema.FieldsByDBName["other"],
+			expected: fieldPolicy{},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := policyOf(testData.field)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestSearchable_ReturnsExpectedResult(t *testing.T) {
+	t.Parallel()
+
+	statement := &gorm.Statement{DB: gormtestutil.NewMemoryDatabase(t)}
+	require.NoError(t, statement.Parse(&ObjectM{}))
+
+	tests := map[string]struct {
+		field      string
+		taggedOnly bool
+
+		expected bool
+	}{
+		"configured":                  {field: "name", expected: true},
+		"configured, tagged only":     {field: "name", taggedOnly: true, expected: true},
+		"disabled":                    {field: "secret"},
+		"tagged false":                {field: "nickname"},
+		"not configured":              {field: "other", expected: true},
+		"not configured, tagged only": {field: "other", taggedOnly: true},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := Searchable(statement.Schema.FieldsByDBName[testData.field], testData.taggedOnly)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package gormlike
+
+import (
+	"slices"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// Compile-time interface check
+var _ gorm.Plugin = new(Controller)
+
+// Controller is the plugin with a configuration that can be changed while queries are running, e.g. to toggle
+// TaggedOnly() from a feature flag without opening the database again. Register it using db.Use like New().
+type Controller struct {
+	name    string
+	current atomic.Pointer[gormLike]
+}
+
+// NewController creates a plugin like New() does, whose configuration can be changed using Update.
+func NewController(opts ...Option) *Controller {
+	config := newGormLike(opts...)
+
+	controller := &Controller{name: config.name}
+	controller.current.Store(config)
+
+	return controller
+}
+
+// Update replaces the configuration with the given options, options given earlier are not kept. Queries that already
+// started finish using the old configuration, queries that start afterwards use the new one. The callbacks are
+// registered already, so the name given to WithName() in NewController is kept.
+func (c *Controller) Update(opts ...Option) {
+	c.current.Store(newGormLike(slices.Concat(opts, []Option{WithName(c.name)})...))
+}
+
+func (c *Controller) Name() string {
+	return c.name
+}
+
+// Initialize registers the callbacks, including those of the observer as WithObserver() might be given later on
+func (c *Controller) Initialize(db *gorm.DB) error {
+	return register(db, c.current.Load(), c.queryCallback, c.observeCallback, true)
+}
+
+func (c *Controller) queryCallback(db *gorm.DB) {
+	c.current.Load().queryCallback(db)
+}
+
+func (c *Controller) observeCallback(db *gorm.DB) {
+	c.current.Load().observeCallback(db)
+}
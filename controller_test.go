@@ -0,0 +1,128 @@
+package gormlike
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type ObjectQ struct {
+	ID    int
+	Name  string `gormlike:"true"`
+	Other string
+}
+
+func TestController_Name_ReturnsExpectedName(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	controller := NewController()
+
+	// Act
+	result := controller.Name()
+
+	// Assert
+	assert.Equal(t, "gormlike", result)
+}
+
+func TestController_Initialize_RegistersCallbacks(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t)
+	controller := NewController()
+
+	// Act
+	err := controller.Initialize(db)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotNil(t, db.Callback().Query().Get("gormlike:query"))
+	assert.NotNil(t, db.Callback().Row().Get("gormlike:row"))
+	assert.NotNil(t, db.Callback().Query().Get("gormlike:observe_query"))
+	assert.NotNil(t, db.Callback().Row().Get("gormlike:observe_row"))
+}
+
+func TestController_Update_ReplacesConfiguration(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectQ{})
+
+	existing := []ObjectQ{{ID: 1, Name: "jessica", Other: "amy"}, {ID: 2, Name: "john", Other: "%a%"}}
+	require.NoError(t, db.Create(&existing).Error)
+
+	observer := &MemoryObserver{}
+	controller := NewController()
+	require.NoError(t, db.Use(controller))
+
+	filter := map[string]any{"other": "%a%"}
+
+	var before []ObjectQ
+	require.NoError(t, db.Where(filter).Order("id").Find(&before).Error)
+
+	// Act
+	controller.Update(TaggedOnly(), WithObserver(observer))
+
+	// Assert
+	var after []ObjectQ
+	require.NoError(t, db.Where(filter).Order("id").Find(&after).Error)
+
+	var tagged []ObjectQ
+	require.NoError(t, db.Where(map[string]any{"name": "j%"}).Order("id").Find(&tagged).Error)
+
+	assert.Equal(t, existing, before)
+	assert.Equal(t, []ObjectQ{existing[1]}, after)
+	assert.Equal(t, existing, tagged)
+	assert.Len(t, observer.Observations(), 1)
+}
+
+func TestController_Update_IsSafeWhileQuerying(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectQ{})
+
+	controller := NewController()
+	require.NoError(t, db.Use(controller))
+
+	session := db.Session(&gorm.Session{DryRun: true})
+	configurations := [][]Option{
+		{},
+		{TaggedOnly()},
+		{WithCharacter("*"), Strict()},
+		{Tokenised(), WithTokenColumns("name", "other"), WithObserver(&MemoryObserver{})},
+	}
+
+	var waitGroup sync.WaitGroup
+
+	// Act
+	for range 8 {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			for range 200 {
+				var result []ObjectQ
+
+				// Errors of Strict() are expected, the point is that the configuration is never read halfway
+				_ = session.Where(map[string]any{"name": "j%", "other": "*a"}).Find(&result)
+			}
+		}()
+	}
+
+	for index := range 200 {
+		controller.Update(configurations[index%len(configurations)]...)
+	}
+
+	waitGroup.Wait()
+
+	// Assert
+	var result []ObjectQ
+	query := session.Where(map[string]any{"name": "j%"}).Find(&result)
+	require.NoError(t, query.Error)
+	assert.Contains(t, query.Statement.SQL.String(), "LIKE")
+}
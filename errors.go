@@ -0,0 +1,198 @@
+package gormlike
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrUnsupportedValue is returned in Strict() mode if a wildcard is requested with a value that is not a string
+	ErrUnsupportedValue = errors.New("gormlike: " + ReasonUnsupportedValue)
+
+	// ErrUnsupportedColumn is returned in Strict() mode if a wildcard is requested on something that is not a column
+	ErrUnsupportedColumn = errors.New("gormlike: " + ReasonUnsupportedColumn)
+
+	// ErrUnknownField is returned in Strict() mode if a wildcard is requested on a column that can't be found
+	ErrUnknownField = errors.New("gormlike: " + ReasonUnknownField)
+
+	// ErrFieldNotLikeable is returned in Strict() mode if a wildcard is requested on a field that is not likeable,
+	// because of its tag or because of TaggedOnly()
+	ErrFieldNotLikeable = errors.New("gormlike: " + ReasonFieldNotLikeable)
+
+	// ErrPatternNotAllowed is returned in Strict() mode if a pattern is used on a field that only allows other kinds of
+	// patterns, e.g. %jo on a field tagged with `gormlike:"true;match:prefix"`
+	ErrPatternNotAllowed = errors.New("gormlike: " + ReasonPatternNotAllowed)
+
+	// ErrInvalidOperand is returned in Strict() mode if the value after an operator of WithOperators() doesn't fit the
+	// type of the field, e.g. >=abc on a number
+	ErrInvalidOperand = errors.New("gormlike: " + ReasonInvalidOperand)
+
+	// ErrPatternTooLong is returned in Strict() mode if a pattern has more characters than allowed by WithLimits()
+	ErrPatternTooLong = errors.New("gormlike: " + ReasonPatternTooLong)
+
+	// ErrTooManyWildcards is returned in Strict() mode if a pattern has more wildcards than allowed by WithLimits()
+	ErrTooManyWildcards = errors.New("gormlike: " + ReasonTooManyWildcards)
+
+	// ErrTooManyTerms is returned in Strict() mode if a value would be turned into more LIKE conditions than allowed
+	// by WithLimits(), e.g. an IN list with too many patterns
+	ErrTooManyTerms = errors.New("gormlike: " + ReasonTooManyTerms)
+
+	// ErrUnknownCallback is returned when the plugin is registered if a callback given to RunBefore(), RunAfter(),
+	// ObserveBefore() or ObserveAfter() isn't registered yet
+	ErrUnknownCallback = errors.New("gormlike: unknown callback")
+
+	// ErrUnsupportedDialect is returned by Migrate() if the database is not PostgreSQL, MySQL or SQLite
+	ErrUnsupportedDialect = errors.New("gormlike: unsupported dialect")
+)
+
+// reasonErrors are the errors that are returned in Strict() mode if a condition is skipped for the given reason
+var reasonErrors = map[string]error{
+	ReasonUnsupportedValue:  ErrUnsupportedValue,
+	ReasonUnsupportedColumn: ErrUnsupportedColumn,
+	ReasonUnknownField:      ErrUnknownField,
+	ReasonFieldNotLikeable:  ErrFieldNotLikeable,
+	ReasonPatternNotAllowed: ErrPatternNotAllowed,
+	ReasonInvalidOperand:    ErrInvalidOperand,
+	ReasonPatternTooLong:    ErrPatternTooLong,
+	ReasonTooManyWildcards:  ErrTooManyWildcards,
+	ReasonTooManyTerms:      ErrTooManyTerms,
+}
+
+// skip records that a condition is left alone, in Strict() mode an error is added to the statement if a wildcard
+// was requested
+func (d *gormLike) skip(db *gorm.DB, src source, column any, reason string) {
+	decision := newDecision(db, src, column, false, reason)
+	d.record(db, decision)
+
+	err, ok := reasonErrors[reason]
+	if !ok || !d.strict {
+		return
+	}
+
+	err = fmt.Errorf("%w: %s", err, decision.Column)
+	_ = db.AddError(err)
+
+	// GORM drops the SQL of a subquery that fails, so the query it's part of has to fail instead
+	if parent, ok := db.Get(parentKey(d.name)); ok {
+		if parentDB, ok := parent.(*gorm.DB); ok {
+			_ = parentDB.AddError(err)
+		}
+	}
+}
+
+// parentKey is the setting of a subquery that holds the query it's part of, GORM builds subqueries in a session of
+// their own and ignores their errors
+func parentKey(name string) string {
+	return name + ":parent"
+}
+
+// adoptSubqueries returns the expression with its subqueries reporting their errors to the query in Strict() mode
+func (d *gormLike) adoptSubqueries(db *gorm.DB, expression clause.Expression) clause.Expression {
+	if !d.strict {
+		return expression
+	}
+
+	switch expression := expression.(type) {
+	case clause.Expr:
+		expression.Vars = d.adoptSubqueryValues(db, expression.Vars)
+
+		return expression
+	case *clause.Expr:
+		if expression == nil {
+			return expression
+		}
+
+		result := *expression
+		result.Vars = d.adoptSubqueryValues(db, result.Vars)
+
+		return &result
+	case clause.NamedExpr:
+		expression.Vars = d.adoptSubqueryValues(db, expression.Vars)
+
+		return expression
+	case clause.IN:
+		expression.Values = d.adoptSubqueryValues(db, expression.Values)
+
+		return expression
+	case clause.Eq:
+		expression.Value = d.adoptSubquery(db, expression.Value)
+
+		return expression
+	default:
+		return expression
+	}
+}
+
+// adoptSubqueryValues returns a copy of the values in which the subqueries report their errors to the query
+func (d *gormLike) adoptSubqueryValues(db *gorm.DB, values []any) []any {
+	result := make([]any, len(values))
+	for index, value := range values {
+		result[index] = d.adoptSubquery(db, value)
+	}
+
+	return result
+}
+
+// adoptSubquery returns a session of the value that reports its errors to the outermost query if it's a subquery,
+// named arguments like @orders are looked up in maps
+func (d *gormLike) adoptSubquery(db *gorm.DB, value any) any {
+	switch value := value.(type) {
+	case *gorm.DB:
+		root := db
+		if parent, ok := db.Get(parentKey(d.name)); ok {
+			if parentDB, ok := parent.(*gorm.DB); ok {
+				root = parentDB
+			}
+		}
+
+		return value.Session(&gorm.Session{}).Set(parentKey(d.name), root)
+	case map[string]any:
+		result := make(map[string]any, len(value))
+		for key, item := range value {
+			result[key] = d.adoptSubquery(db, item)
+		}
+
+		return result
+	default:
+		return value
+	}
+}
+
+// hasWildcard returns whether the value contains a % or the replacement character
+func (d *gormLike) hasWildcard(value string) bool {
+	return strings.Contains(value, "%") || (d.replaceCharacter != "" && strings.Contains(value, d.replaceCharacter))
+}
+
+// replaceWildcards replaces the replacement character in the value with %
+func (d *gormLike) replaceWildcards(value string) string {
+	if d.replaceCharacter == "" {
+		return value
+	}
+
+	return strings.ReplaceAll(value, d.replaceCharacter, "%")
+}
+
+// wantsLike returns whether the value asks for a LIKE query, either through a wildcard or because the condition is
+// tokenised
+func (d *gormLike) wantsLike(value string, tokenise bool) bool {
+	return tokenise || d.hasWildcard(value)
+}
+
+// wantsUnsupportedLike returns whether a value that isn't a string, like a *string or a []byte, asks for a LIKE query
+func (d *gormLike) wantsUnsupportedLike(value any, tokenise bool) bool {
+	if bytes, ok := value.([]byte); ok {
+		return d.wantsLike(string(bytes), tokenise)
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	for reflectValue.Kind() == reflect.Pointer && !reflectValue.IsNil() {
+		reflectValue = reflectValue.Elem()
+	}
+
+	return reflectValue.Kind() == reflect.String && d.wantsLike(reflectValue.String(), tokenise)
+}
@@ -0,0 +1,139 @@
+package gormlike
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestGormLike_Initialize_ReturnsErrorsInStrictMode(t *testing.T) {
+	t.Parallel()
+
+	type ObjectG struct {
+		ID     int
+		Name   string `gormlike:"true"`
+		Other  string
+		Hidden string `gormlike:"false"`
+	}
+
+	jessica := ObjectG{ID: 1, Name: "jessica", Other: "abc", Hidden: "ghi"}
+	amy := ObjectG{ID: 2, Name: "amy", Other: "def", Hidden: "jkl"}
+
+	pointer := "%b%"
+
+	tests := map[string]struct {
+		query    func(*gorm.DB) *gorm.DB
+		options  []Option
+		expected []ObjectG
+		err      error
+	}{
+		"likeable field": {
+			query:    func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "jes%"}) },
+			expected: []ObjectG{jessica},
+		},
+		"likeable field in list": {
+			query:    func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": []string{"jes%", "amy"}}) },
+			expected: []ObjectG{jessica, amy},
+		},
+		"normal query on field that is not likeable": {
+			query:    func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"hidden": "ghi"}) },
+			expected: []ObjectG{jessica},
+		},
+		"normal query with a value that is not a string": {
+			query:    func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"id": 2}) },
+			expected: []ObjectG{amy},
+		},
+		"field with tag false": {
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"hidden": "%h%"}) },
+			err:   ErrFieldNotLikeable,
+		},
+		"field with tag false in list": {
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"hidden": []string{"ghi", "%h%"}}) },
+			err:   ErrFieldNotLikeable,
+		},
+		"untagged field in tagged only mode": {
+			query:   func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"other": "%b%"}) },
+			options: []Option{TaggedOnly()},
+			err:     ErrFieldNotLikeable,
+		},
+		"unknown field in tagged only mode": {
+			query:   func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"unknown": "%b%"}) },
+			options: []Option{TaggedOnly()},
+			err:     ErrUnknownField,
+		},
+		"unknown association field": {
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"unknown.name": "%b%"}) },
+			err:   ErrUnknownField,
+		},
+		"pointer to a string": {
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"other": &pointer}) },
+			err:   ErrUnsupportedValue,
+		},
+		"bytes": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(clause.Eq{Column: clause.Column{Name: "other"}, Value: []byte("%b%")})
+			},
+			err: ErrUnsupportedValue,
+		},
+		"expression instead of a column": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(clause.Eq{Column: clause.Expr{SQL: "LOWER(other)"}, Value: "%b%"})
+			},
+			err: ErrUnsupportedColumn,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectG{})
+			require.NoError(t, db.Create([]ObjectG{jessica, amy}).Error)
+
+			require.NoError(t, db.Use(New(append(testData.options, Strict())...)))
+
+			var actual []ObjectG
+
+			// Act
+			err := testData.query(db).Find(&actual).Error
+
+			// Assert
+			if testData.err != nil {
+				require.ErrorIs(t, err, testData.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
+
+func TestGormLike_Initialize_IgnoresErrorsWithoutStrictMode(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	type ObjectG struct {
+		ID     int
+		Hidden string `gormlike:"false"`
+	}
+
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectG{})
+	require.NoError(t, db.Create(&ObjectG{ID: 1, Hidden: "abc"}).Error)
+	require.NoError(t, db.Use(New()))
+
+	var actual []ObjectG
+
+	// Act
+	err := db.Where(map[string]any{"hidden": "%b%"}).Find(&actual).Error
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, actual)
+}
@@ -0,0 +1,258 @@
+package gormlike
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// explainKey returns the setting that makes the plugin with the given name record its decisions onto the statement
+func explainKey(name string) string {
+	return name + ":explain"
+}
+
+// decisionsKey returns the instance setting that the decisions of the plugin with the given name are recorded in
+func decisionsKey(name string) string {
+	return name + ":decisions"
+}
+
+// Reasons for leaving a condition alone, found in Decision.Reason
+const (
+	// ReasonNoWildcard means that the value doesn't contain a wildcard, so it's a normal query
+	ReasonNoWildcard = "no wildcard"
+
+	// ReasonSettingDisabled means that the plugin is disabled for the query using the `gormlike` setting
+	ReasonSettingDisabled = "disabled by setting"
+
+	// ReasonUnsupportedValue means that a wildcard was requested with a value that is not a string
+	ReasonUnsupportedValue = "value is not a string"
+
+	// ReasonUnsupportedColumn means that a wildcard was requested on something that is not a column
+	ReasonUnsupportedColumn = "not a column"
+
+	// ReasonUnknownField means that a wildcard was requested on a column that can't be found
+	ReasonUnknownField = "unknown field"
+
+	// ReasonFieldNotLikeable means that a wildcard was requested on a field that is not likeable
+	ReasonFieldNotLikeable = "field is not likeable"
+
+	// ReasonPatternNotAllowed means that the field only allows other kinds of patterns, e.g. only prefixes
+	ReasonPatternNotAllowed = "pattern not allowed"
+
+	// ReasonInvalidOperand means that the value after an operator of WithOperators() doesn't fit the type of the field
+	ReasonInvalidOperand = "invalid operand"
+
+	// ReasonPatternTooLong means that the pattern has more characters than allowed by WithLimits()
+	ReasonPatternTooLong = "pattern too long"
+
+	// ReasonTooManyWildcards means that the pattern has more wildcards than allowed by WithLimits()
+	ReasonTooManyWildcards = "too many wildcards"
+
+	// ReasonTooManyTerms means that a value would be turned into more LIKE conditions than allowed by WithLimits()
+	ReasonTooManyTerms = "too many terms"
+)
+
+// Decision describes what the plugin did with a condition of a query
+type Decision struct {
+	// Table is the table or alias of the column, empty if unknown
+	Table string
+
+	// Column is the name of the column, empty if the decision applies to the whole query
+	Column string
+
+	// Converted is true if the condition was turned into a LIKE query
+	Converted bool
+
+	// Reason is why the condition was left alone, empty if it was converted
+	Reason string
+
+	// Pattern is the kind of pattern the column is matched with, empty if the condition was left alone
+	Pattern PatternKind
+
+	// Value is the pattern the column is matched with, empty if the condition was left alone
+	Value string
+
+	// SQL is the condition the column is matched with, empty if the condition was left alone
+	SQL string
+}
+
+// String returns a human-readable description of the decision, e.g. `users.name skipped: no wildcard`
+func (d Decision) String() string {
+	name := d.Column
+	if d.Table != "" && d.Column != "" {
+		name = d.Table + "." + d.Column
+	}
+
+	if d.Converted {
+		return strings.TrimSpace(name + " converted")
+	}
+
+	return strings.TrimSpace(name + " skipped: " + d.Reason)
+}
+
+// Explanation returns the decisions the plugin made for the query that was executed on tx, this requires the
+// `gormlike:explain` setting to be set to true on the query, e.g.
+//
+//	tx := db.Set("gormlike:explain", true).Where(...).Find(&users)
+//	decisions := gormlike.Explanation(tx)
+//
+// Without names it returns the decisions of the plugin named gormlike, otherwise those of the plugins with the names
+// given to WithName(), which are enabled using their own setting, e.g. `search:explain`.
+func Explanation(tx *gorm.DB, names ...string) []Decision {
+	if len(names) == 0 {
+		names = []string{tagName}
+	}
+
+	var result []Decision
+
+	for _, name := range names {
+		decisions, ok := tx.InstanceGet(decisionsKey(name))
+		if !ok {
+			continue
+		}
+
+		if decisionsPointer, _ := decisions.(*[]Decision); decisionsPointer != nil {
+			result = append(result, *decisionsPointer...)
+		}
+	}
+
+	return result
+}
+
+// newDecision creates a decision for the given column, the table is taken from the column if it has one
+func newDecision(db *gorm.DB, src source, column any, converted bool, reason string) Decision {
+	result := Decision{Converted: converted, Reason: reason}
+
+	columnValue, ok := column.(clause.Column)
+	if !ok {
+		if expression, ok := column.(clause.Expr); ok {
+			column = expression.SQL
+		}
+
+		result.Column = fmt.Sprint(column)
+
+		return result
+	}
+
+	result.Column = columnValue.Name
+
+	switch columnValue.Table {
+	case "":
+	case clause.CurrentTable:
+		result.Table = src.table
+	default:
+		result.Table = columnValue.Table
+	}
+
+	if result.Table == clause.CurrentTable {
+		result.Table = db.Statement.Table
+	}
+
+	return result
+}
+
+// converted records that a condition on the column was turned into the given LIKE query with the given pattern
+func (d *gormLike) converted(db *gorm.DB, src source, column clause.Column, condition, pattern string) {
+	decision := newDecision(db, src, column, true, "")
+	decision.Pattern = patternKind(pattern)
+	decision.Value = pattern
+	decision.SQL = condition
+
+	d.record(db, decision)
+}
+
+// collecting returns whether the decisions for the statement should be recorded, either for logging or because
+// the explain setting of the plugin is set
+func (d *gormLike) collecting(db *gorm.DB) bool {
+	if d.logger != nil || d.observer != nil || d.indexAdvice {
+		return true
+	}
+
+	explain, _ := db.Get(explainKey(d.name))
+	explainValue, _ := explain.(bool)
+
+	return explainValue
+}
+
+// record adds the decision to the statement if decisions are being collected
+func (d *gormLike) record(db *gorm.DB, decision Decision) {
+	decisions, ok := db.InstanceGet(decisionsKey(d.name))
+	if !ok {
+		return
+	}
+
+	if decisionsPointer, _ := decisions.(*[]Decision); decisionsPointer != nil {
+		*decisionsPointer = append(*decisionsPointer, decision)
+	}
+}
+
+// logDecisions writes the decisions of the statement to the logger, if any
+func (d *gormLike) logDecisions(db *gorm.DB) {
+	decisions := Explanation(db, d.name)
+	if d.logger == nil || len(decisions) == 0 {
+		return
+	}
+
+	d.logger.logDecisions(db.Statement.Context, db.Statement.Table, decisions)
+}
+
+// decisionLogger writes what the plugin did with a query somewhere
+type decisionLogger interface {
+	// logDecisions writes the decisions made for a query on the table
+	logDecisions(ctx context.Context, table string, decisions []Decision)
+
+	// logIndexWarnings writes the warnings of the index advisor
+	logIndexWarnings(ctx context.Context, warnings []IndexWarning)
+}
+
+// gormDecisionLogger logs the decisions at info level, as gorm's logger has no debug level
+type gormDecisionLogger struct {
+	logger logger.Interface
+}
+
+func (g gormDecisionLogger) logDecisions(ctx context.Context, table string, decisions []Decision) {
+	descriptions := make([]string, 0, len(decisions))
+	for _, decision := range decisions {
+		descriptions = append(descriptions, decision.String())
+	}
+
+	g.logger.Info(ctx, "gormlike: query on %s: %s", table, strings.Join(descriptions, ", "))
+}
+
+func (g gormDecisionLogger) logIndexWarnings(ctx context.Context, warnings []IndexWarning) {
+	for _, warning := range warnings {
+		g.logger.Warn(ctx, "gormlike: %s", warning.String())
+	}
+}
+
+// slogDecisionLogger logs the decisions at debug level
+type slogDecisionLogger struct {
+	logger *slog.Logger
+}
+
+func (s slogDecisionLogger) logDecisions(ctx context.Context, table string, decisions []Decision) {
+	var converted, skipped []string
+
+	for _, decision := range decisions {
+		if decision.Converted {
+			converted = append(converted, decision.String())
+		} else {
+			skipped = append(skipped, decision.String())
+		}
+	}
+
+	s.logger.DebugContext(ctx, "gormlike: rewrote conditions",
+		slog.String("table", table), slog.Any("converted", converted), slog.Any("skipped", skipped))
+}
+
+func (s slogDecisionLogger) logIndexWarnings(ctx context.Context, warnings []IndexWarning) {
+	for _, warning := range warnings {
+		s.logger.WarnContext(ctx, "gormlike: "+warning.String(),
+			slog.String("table", warning.Table), slog.String("column", warning.Column), slog.String("index", warning.Index))
+	}
+}
@@ -0,0 +1,225 @@
+package gormlike
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+type ObjectH struct {
+	ID     int
+	Name   string
+	Hidden string `gormlike:"false"`
+}
+
+func TestExplanation_ReturnsDecisions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		query    func(*gorm.DB) *gorm.DB
+		options  []Option
+		expected []Decision
+	}{
+		"converted": {
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "j%"}) },
+			expected: []Decision{
+				{Table: "object_hs", Column: "name", Converted: true, Pattern: PatternPrefix, Value: "j%", SQL: "CAST(`object_hs`.`name` as varchar) LIKE ?"},
+			},
+		},
+		"converted list": {
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": []string{"j%", "amy"}}) },
+			expected: []Decision{
+				{Table: "object_hs", Column: "name", Converted: true, Pattern: PatternPrefix, Value: "j%", SQL: "CAST(`object_hs`.`name` as varchar) LIKE ?"},
+			},
+		},
+		"no wildcard": {
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "jessica", "id": 1}) },
+			expected: []Decision{
+				{Table: "object_hs", Column: "id", Reason: ReasonNoWildcard},
+				{Table: "object_hs", Column: "name", Reason: ReasonNoWildcard},
+			},
+		},
+		"tag false": {
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"hidden": "%a%"}) },
+			expected: []Decision{
+				{Table: "object_hs", Column: "hidden", Reason: ReasonFieldNotLikeable},
+			},
+		},
+		"unknown field in tagged only mode": {
+			query:   func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"unknown": "%a%"}) },
+			options: []Option{TaggedOnly()},
+			expected: []Decision{
+				{Table: "object_hs", Column: "unknown", Reason: ReasonUnknownField},
+			},
+		},
+		"non-string value": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(clause.Eq{Column: clause.Column{Name: "name"}, Value: []byte("j%")})
+			},
+			expected: []Decision{
+				{Column: "name", Reason: ReasonUnsupportedValue},
+			},
+		},
+		"not a column": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(clause.Eq{Column: clause.Expr{SQL: "LOWER(name)"}, Value: "j%"})
+			},
+			expected: []Decision{
+				{Column: "LOWER(name)", Reason: ReasonUnsupportedColumn},
+			},
+		},
+		"setting disabled": {
+			query: func(db *gorm.DB) *gorm.DB { return db.Set("gormlike", false).Where(map[string]any{"name": "j%"}) },
+			expected: []Decision{
+				{Table: "object_hs", Reason: ReasonSettingDisabled},
+			},
+		},
+		"setting missing in setting only mode": {
+			query:   func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "j%"}) },
+			options: []Option{SettingOnly()},
+			expected: []Decision{
+				{Table: "object_hs", Reason: ReasonSettingDisabled},
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectH{})
+			require.NoError(t, db.Use(New(testData.options...)))
+
+			var actual []ObjectH
+
+			// Act
+			tx := testData.query(db.Session(&gorm.Session{DryRun: true}).Set("gormlike:explain", true)).Find(&actual)
+
+			// Assert
+			require.NoError(t, tx.Error)
+			assert.ElementsMatch(t, testData.expected, Explanation(tx))
+		})
+	}
+}
+
+func TestExplanation_ReturnsNothingWithoutSetting(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t)
+	_ = db.AutoMigrate(&ObjectH{})
+	require.NoError(t, db.Use(New()))
+
+	var actual []ObjectH
+
+	// Act
+	tx := db.Where(map[string]any{"name": "j%"}).Find(&actual)
+
+	// Assert
+	require.NoError(t, tx.Error)
+	assert.Nil(t, Explanation(tx))
+}
+
+func TestDecision_String_ReturnsExpectedDescription(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		decision Decision
+		expected string
+	}{
+		"converted": {
+			decision: Decision{Table: "users", Column: "name", Converted: true},
+			expected: "users.name converted",
+		},
+		"skipped": {
+			decision: Decision{Table: "users", Column: "name", Reason: ReasonNoWildcard},
+			expected: "users.name skipped: no wildcard",
+		},
+		"without table": {
+			decision: Decision{Column: "name", Reason: ReasonUnsupportedValue},
+			expected: "name skipped: value is not a string",
+		},
+		"whole query": {
+			decision: Decision{Table: "users", Reason: ReasonSettingDisabled},
+			expected: "skipped: disabled by setting",
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := testData.decision.String()
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestGormLike_Initialize_LogsDecisionsToSlog(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var output bytes.Buffer
+
+	slogLogger := slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	db := gormtestutil.NewMemoryDatabase(t)
+	_ = db.AutoMigrate(&ObjectH{})
+	require.NoError(t, db.Use(New(WithSlogLogger(slogLogger))))
+
+	var actual []ObjectH
+
+	// Act
+	err := db.Where(map[string]any{"name": "j%", "hidden": "%a%"}).Find(&actual).Error
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), "level=DEBUG")
+	assert.Contains(t, output.String(), "table=object_hs")
+	assert.Contains(t, output.String(), `converted="[object_hs.name converted]"`)
+	assert.Contains(t, output.String(), `skipped="[object_hs.hidden skipped: field is not likeable]"`)
+}
+
+// recordingLogger is a logger.Interface that keeps the messages logged at info and warn level
+type recordingLogger struct {
+	logger.Interface
+
+	messages []string
+}
+
+func (r *recordingLogger) Info(_ context.Context, message string, args ...any) {
+	r.messages = append(r.messages, fmt.Sprintf(message, args...))
+}
+
+func (r *recordingLogger) Warn(_ context.Context, message string, args ...any) {
+	r.messages = append(r.messages, fmt.Sprintf(message, args...))
+}
+
+func TestGormLike_Initialize_LogsDecisionsToGormLogger(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	gormLogger := &recordingLogger{Interface: logger.Discard}
+
+	db := gormtestutil.NewMemoryDatabase(t)
+	_ = db.AutoMigrate(&ObjectH{})
+	require.NoError(t, db.Use(New(WithLogger(gormLogger))))
+
+	var actual []ObjectH
+
+	// Act
+	err := db.Where(map[string]any{"name": "j%"}).Find(&actual).Error
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gormlike: query on object_hs: object_hs.name converted"}, gormLogger.messages)
+}
@@ -0,0 +1,196 @@
+// Package filter turns query strings and JSON documents from clients into conditions for the gormlike plugin. Keys
+// are validated against the schema of the model, so only columns that are searchable end up in the query.
+package filter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	gormlike "github.com/survivorbat/gorm-like"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidValue is returned if a value in a JSON document is not a string, number, boolean, null or a list of those
+var ErrInvalidValue = errors.New("gormlike/filter: invalid value")
+
+// Option can be given to the parse functions to tweak their behaviour
+type Option func(parser *parser)
+
+// TaggedOnly only accepts keys of fields with the tag `gormlike:"true"`, like gormlike.TaggedOnly() does
+func TaggedOnly() Option {
+	return func(parser *parser) {
+		parser.taggedOnly = true
+	}
+}
+
+// WithSeparator splits query string values into a list on the given separator instead of a comma, an empty
+// separator turns this off. Lists can always be given by repeating the key, e.g. ?role=admin&role=dev.
+func WithSeparator(separator string) Option {
+	return func(parser *parser) {
+		parser.separator = separator
+	}
+}
+
+type parser struct {
+	taggedOnly bool
+	separator  string
+}
+
+func newParser(opts ...Option) *parser {
+	result := &parser{separator: ","}
+
+	for _, opt := range opts {
+		opt(result)
+	}
+
+	return result
+}
+
+// ParseValues turns query string values like ?name=jo%&role=admin,dev into a filter for the model, e.g.
+// {"name": "jo%", "role": ["admin", "dev"]}. Returns an error wrapping gormlike.ErrUnknownField or
+// gormlike.ErrFieldNotLikeable if a key is not a searchable column of the model.
+func ParseValues(db *gorm.DB, model any, values url.Values, opts ...Option) (map[string]any, error) {
+	parser := newParser(opts...)
+	result := make(map[string]any, len(values))
+
+	for key, keyValues := range values {
+		var list []string
+
+		for _, value := range keyValues {
+			if parser.separator == "" {
+				list = append(list, value)
+
+				continue
+			}
+
+			list = append(list, strings.Split(value, parser.separator)...)
+		}
+
+		if len(list) == 1 {
+			result[key] = list[0]
+		} else {
+			result[key] = list
+		}
+	}
+
+	if err := parser.validate(db, model, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ParseJSON turns a JSON document like {"name": "jo%", "role": ["admin", "dev"]} into a filter for the model. Returns
+// an error wrapping gormlike.ErrUnknownField or gormlike.ErrFieldNotLikeable if a key is not a searchable column of
+// the model, or ErrInvalidValue if a value is an object.
+func ParseJSON(db *gorm.DB, model any, document []byte, opts ...Option) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+
+	var result map[string]any
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidValue, err)
+	}
+
+	for key, value := range result {
+		converted, err := convertJSON(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, key)
+		}
+
+		result[key] = converted
+	}
+
+	if err := newParser(opts...).validate(db, model, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FromValues returns a scope that applies the filter of ParseValues, e.g. db.Scopes(scope).Find(&users)
+func FromValues(db *gorm.DB, model any, values url.Values, opts ...Option) (func(*gorm.DB) *gorm.DB, error) {
+	filter, err := ParseValues(db, model, values, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return scope(filter), nil
+}
+
+// FromJSON returns a scope that applies the filter of ParseJSON, e.g. db.Scopes(scope).Find(&users)
+func FromJSON(db *gorm.DB, model any, document []byte, opts ...Option) (func(*gorm.DB) *gorm.DB, error) {
+	filter, err := ParseJSON(db, model, document, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return scope(filter), nil
+}
+
+// scope returns a scope that adds the filter to the query
+func scope(filter map[string]any) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(filter) == 0 {
+			return db
+		}
+
+		return db.Where(filter)
+	}
+}
+
+// validate returns an error if any of the keys is not a searchable column of the model
+func (p *parser) validate(db *gorm.DB, model any, filter map[string]any) error {
+	statement := &gorm.Statement{DB: db}
+	if err := statement.Parse(model); err != nil {
+		return err
+	}
+
+	for key := range filter {
+		field, ok := statement.Schema.FieldsByDBName[key]
+		if !ok {
+			return fmt.Errorf("%w: %s", gormlike.ErrUnknownField, key)
+		}
+
+		if !gormlike.Searchable(field, p.taggedOnly) {
+			return fmt.Errorf("%w: %s", gormlike.ErrFieldNotLikeable, key)
+		}
+	}
+
+	return nil
+}
+
+// convertJSON turns numbers into int64 or float64 values and returns an error for objects and nested lists
+func convertJSON(value any) (any, error) {
+	switch value := value.(type) {
+	case json.Number:
+		if integer, err := value.Int64(); err == nil {
+			return integer, nil
+		}
+
+		return value.Float64()
+	case []any:
+		for index, element := range value {
+			if _, ok := element.([]any); ok {
+				return nil, ErrInvalidValue
+			}
+
+			converted, err := convertJSON(element)
+			if err != nil {
+				return nil, err
+			}
+
+			value[index] = converted
+		}
+
+		return value, nil
+	case map[string]any:
+		return nil, ErrInvalidValue
+	default:
+		return value, nil
+	}
+}
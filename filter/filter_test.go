@@ -0,0 +1,243 @@
+package filter
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormlike "github.com/survivorbat/gorm-like"
+	"gorm.io/gorm"
+)
+
+type User struct {
+	ID       int
+	Name     string `gormlike:"true"`
+	Role     string
+	Age      int
+	Password string `gormlike:"false"`
+}
+
+func TestParseValues_ReturnsExpectedFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		query   string
+		options []Option
+
+		expected    map[string]any
+		expectedErr error
+	}{
+		"empty": {
+			expected: map[string]any{},
+		},
+		"single values": {
+			query:    "name=jo%25&age=18",
+			expected: map[string]any{"name": "jo%", "age": "18"},
+		},
+		"separated list": {
+			query:    "role=admin,dev",
+			expected: map[string]any{"role": []string{"admin", "dev"}},
+		},
+		"repeated key": {
+			query:    "role=admin&role=dev,ops",
+			expected: map[string]any{"role": []string{"admin", "dev", "ops"}},
+		},
+		"other separator": {
+			query:    "role=admin,dev|ops",
+			options:  []Option{WithSeparator("|")},
+			expected: map[string]any{"role": []string{"admin,dev", "ops"}},
+		},
+		"without separator": {
+			query:    "role=admin,dev",
+			options:  []Option{WithSeparator("")},
+			expected: map[string]any{"role": "admin,dev"},
+		},
+		"unknown column": {
+			query:       "email=a%25",
+			expectedErr: gormlike.ErrUnknownField,
+		},
+		"field name instead of column": {
+			query:       "Name=a%25",
+			expectedErr: gormlike.ErrUnknownField,
+		},
+		"injected column": {
+			query:       "name)%20OR%201=1--=a",
+			expectedErr: gormlike.ErrUnknownField,
+		},
+		"column that isn't searchable": {
+			query:       "password=a%25",
+			expectedErr: gormlike.ErrFieldNotLikeable,
+		},
+		"untagged column with tagged only": {
+			query:       "role=admin",
+			options:     []Option{TaggedOnly()},
+			expectedErr: gormlike.ErrFieldNotLikeable,
+		},
+		"tagged column with tagged only": {
+			query:    "name=jo%25",
+			options:  []Option{TaggedOnly()},
+			expected: map[string]any{"name": "jo%"},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+			values, err := url.ParseQuery(testData.query)
+			require.NoError(t, err)
+
+			// Act
+			result, err := ParseValues(db, &User{}, values, testData.options...)
+
+			// Assert
+			if testData.expectedErr != nil {
+				require.ErrorIs(t, err, testData.expectedErr)
+				assert.Nil(t, result)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestParseJSON_ReturnsExpectedFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		document string
+
+		expected    map[string]any
+		expectedErr error
+	}{
+		"values": {
+			document: `{"name": "jo%", "age": 18, "role": null}`,
+			expected: map[string]any{"name": "jo%", "age": int64(18), "role": nil},
+		},
+		"list": {
+			document: `{"role": ["admin", "dev"], "age": [18, 1.5]}`,
+			expected: map[string]any{"role": []any{"admin", "dev"}, "age": []any{int64(18), 1.5}},
+		},
+		"object": {
+			document:    `{"name": {"first": "jo%"}}`,
+			expectedErr: ErrInvalidValue,
+		},
+		"nested list": {
+			document:    `{"role": [["admin"]]}`,
+			expectedErr: ErrInvalidValue,
+		},
+		"invalid document": {
+			document:    `["name"]`,
+			expectedErr: ErrInvalidValue,
+		},
+		"unknown column": {
+			document:    `{"email": "a%"}`,
+			expectedErr: gormlike.ErrUnknownField,
+		},
+		"column that isn't searchable": {
+			document:    `{"password": "a%"}`,
+			expectedErr: gormlike.ErrFieldNotLikeable,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+			// Act
+			result, err := ParseJSON(db, &User{}, []byte(testData.document))
+
+			// Assert
+			if testData.expectedErr != nil {
+				require.ErrorIs(t, err, testData.expectedErr)
+				assert.Nil(t, result)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestFromValues_ReturnsScopeWithFilter(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&User{})
+	require.NoError(t, db.Use(gormlike.New()))
+
+	existing := []User{
+		{ID: 1, Name: "john", Role: "admin"},
+		{ID: 2, Name: "jessica", Role: "guest"},
+		{ID: 3, Name: "joanne", Role: "dev"},
+	}
+	require.NoError(t, db.Create(&existing).Error)
+
+	values, err := url.ParseQuery("name=jo%25&role=admin,dev")
+	require.NoError(t, err)
+
+	// Act
+	scope, err := FromValues(db, &User{}, values)
+
+	// Assert
+	require.NoError(t, err)
+
+	var actual []User
+	require.NoError(t, db.Scopes(scope).Order("id").Find(&actual).Error)
+	assert.Equal(t, []User{existing[0], existing[2]}, actual)
+}
+
+func TestFromJSON_ReturnsScopeWithFilter(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&User{})
+	require.NoError(t, db.Use(gormlike.New()))
+
+	existing := []User{
+		{ID: 1, Name: "john", Age: 20},
+		{ID: 2, Name: "joanne", Age: 30},
+	}
+	require.NoError(t, db.Create(&existing).Error)
+
+	// Act
+	scope, err := FromJSON(db, &User{}, []byte(`{"name": "jo%", "age": 30}`))
+
+	// Assert
+	require.NoError(t, err)
+
+	var actual []User
+	require.NoError(t, db.Scopes(scope).Find(&actual).Error)
+	assert.Equal(t, []User{existing[1]}, actual)
+}
+
+func TestFromJSON_ReturnsError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+	// Act
+	scope, err := FromJSON(db, &User{}, []byte(`{"password": "%"}`))
+
+	// Assert
+	require.ErrorIs(t, err, gormlike.ErrFieldNotLikeable)
+	assert.Nil(t, scope)
+
+	// The scope of an empty filter leaves the query alone
+	scope, err = FromJSON(db, &User{}, []byte(`{}`))
+	require.NoError(t, err)
+
+	query := db.Session(&gorm.Session{DryRun: true}).Scopes(scope).Find(&[]User{})
+	assert.Equal(t, "SELECT * FROM `users`", query.Statement.SQL.String())
+}
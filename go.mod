@@ -6,6 +6,7 @@ require (
 	github.com/google/uuid v1.3.0
 	github.com/ing-bank/gormtestutil v0.0.0
 	github.com/stretchr/testify v1.8.0
+	gorm.io/driver/sqlite v1.4.3
 	gorm.io/gorm v1.30.0
 )
 
@@ -18,5 +19,4 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/text v0.25.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
-	gorm.io/driver/sqlite v1.4.3 // indirect
 )
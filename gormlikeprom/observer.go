@@ -0,0 +1,104 @@
+// Package gormlikeprom provides a gormlike.Observer that exposes wildcard query usage as Prometheus metrics
+package gormlikeprom
+
+import (
+	"context"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	gormlike "github.com/survivorbat/gorm-like"
+)
+
+// Compile-time interface checks
+var (
+	_ gormlike.Observer    = new(Observer)
+	_ prometheus.Collector = new(Observer)
+)
+
+// Observer counts the queries that were turned into LIKE queries per table, the converted columns per kind of
+// pattern and the duration and size of the results. Register it in a prometheus.Registerer and pass it to the plugin:
+//
+//	observer := gormlikeprom.NewObserver()
+//	prometheus.MustRegister(observer)
+//	db.Use(gormlike.New(gormlike.WithObserver(observer)))
+type Observer struct {
+	queries    *prometheus.CounterVec
+	errors     *prometheus.CounterVec
+	conditions *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	rows       *prometheus.HistogramVec
+}
+
+// NewObserver creates the metrics, all of them are prefixed with gormlike_
+func NewObserver() *Observer {
+	return &Observer{
+		queries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gormlike_queries_total",
+			Help: "Number of queries with LIKE conditions.",
+		}, []string{"table"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gormlike_query_errors_total",
+			Help: "Number of queries with LIKE conditions that failed.",
+		}, []string{"table"}),
+		conditions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gormlike_conditions_total",
+			Help: "Number of LIKE conditions per column and kind of pattern.",
+		}, []string{"table", "column", "pattern"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "gormlike_query_duration_seconds",
+			Help:    "Duration of queries with LIKE conditions.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"table"}),
+		rows: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "gormlike_query_rows",
+			Help:    "Number of rows returned by queries with LIKE conditions.",
+			Buckets: prometheus.ExponentialBuckets(1, 4, 8),
+		}, []string{"table"}),
+	}
+}
+
+// Observe updates the metrics using the observation
+func (o *Observer) Observe(_ context.Context, observation gormlike.Observation) {
+	o.queries.WithLabelValues(observation.Table).Inc()
+	o.duration.WithLabelValues(observation.Table).Observe(observation.Elapsed.Seconds())
+
+	// Counting the rows of Row() and Scan() as 0 would skew the histogram
+	if observation.RowsKnown {
+		o.rows.WithLabelValues(observation.Table).Observe(float64(observation.Rows))
+	}
+
+	if observation.Error != nil {
+		o.errors.WithLabelValues(observation.Table).Inc()
+	}
+
+	for _, condition := range observation.Conditions {
+		o.conditions.WithLabelValues(condition.Table, columnLabel(condition), string(condition.Pattern)).Inc()
+	}
+}
+
+// columnLabel returns the column of the condition without its table or the path in a JSON column, e.g. metadata for
+// metadata.customer.name. The keys of a path might come from user input, which would create a series for every one.
+func columnLabel(condition gormlike.Decision) string {
+	column := strings.TrimPrefix(condition.Column, condition.Table+".")
+	column, _, _ = strings.Cut(column, ".")
+
+	return column
+}
+
+// Describe sends the descriptions of all metrics to the channel
+func (o *Observer) Describe(descriptions chan<- *prometheus.Desc) {
+	o.queries.Describe(descriptions)
+	o.errors.Describe(descriptions)
+	o.conditions.Describe(descriptions)
+	o.duration.Describe(descriptions)
+	o.rows.Describe(descriptions)
+}
+
+// Collect sends all metrics to the channel
+func (o *Observer) Collect(metrics chan<- prometheus.Metric) {
+	o.queries.Collect(metrics)
+	o.errors.Collect(metrics)
+	o.conditions.Collect(metrics)
+	o.duration.Collect(metrics)
+	o.rows.Collect(metrics)
+}
@@ -0,0 +1,74 @@
+package gormlikeprom
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormlike "github.com/survivorbat/gorm-like"
+)
+
+func TestObserver_Observe_UpdatesMetrics(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	observer := NewObserver()
+
+	observation := gormlike.Observation{
+		Table: "users",
+		Conditions: []gormlike.Decision{
+			{Table: "users", Column: "name", Converted: true, Pattern: gormlike.PatternPrefix},
+			{Table: "users", Column: "name", Converted: true, Pattern: gormlike.PatternPrefix},
+			{Table: "users", Column: "city", Converted: true, Pattern: gormlike.PatternContains},
+			{Table: "users", Column: "users.name", Converted: true, Pattern: gormlike.PatternPrefix},
+			{Table: "users", Column: "metadata.customer.name", Converted: true, Pattern: gormlike.PatternSuffix},
+			{Table: "users", Column: "metadata.customer.city", Converted: true, Pattern: gormlike.PatternSuffix},
+		},
+		Elapsed:   20 * time.Millisecond,
+		Rows:      3,
+		RowsKnown: true,
+	}
+
+	// Act
+	observer.Observe(context.Background(), observation)
+	observer.Observe(context.Background(), gormlike.Observation{Table: "users", Error: errors.New("failed")})
+
+	// Assert
+	expected := `
+# HELP gormlike_conditions_total Number of LIKE conditions per column and kind of pattern.
+# TYPE gormlike_conditions_total counter
+gormlike_conditions_total{column="city",pattern="contains",table="users"} 1
+gormlike_conditions_total{column="metadata",pattern="suffix",table="users"} 2
+gormlike_conditions_total{column="name",pattern="prefix",table="users"} 3
+# HELP gormlike_queries_total Number of queries with LIKE conditions.
+# TYPE gormlike_queries_total counter
+gormlike_queries_total{table="users"} 2
+# HELP gormlike_query_errors_total Number of queries with LIKE conditions that failed.
+# TYPE gormlike_query_errors_total counter
+gormlike_query_errors_total{table="users"} 1
+`
+
+	err := testutil.CollectAndCompare(observer, strings.NewReader(expected),
+		"gormlike_conditions_total", "gormlike_queries_total", "gormlike_query_errors_total")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(observer, "gormlike_query_duration_seconds"))
+	assert.Equal(t, 1, testutil.CollectAndCount(observer, "gormlike_query_rows"))
+}
+
+func TestObserver_Observe_SkipsUnknownRows(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	observer := NewObserver()
+
+	// Act
+	observer.Observe(context.Background(), gormlike.Observation{Table: "users", Elapsed: time.Millisecond})
+
+	// Assert
+	assert.Equal(t, 1, testutil.CollectAndCount(observer, "gormlike_query_duration_seconds"))
+	assert.Equal(t, 0, testutil.CollectAndCount(observer, "gormlike_query_rows"))
+}
@@ -0,0 +1,32 @@
+package gormlike
+
+import (
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// caseInsensitivePattern matches the conditions of gormcase, e.g. LOWER(name) = LOWER(?) or UPPER("users"."name") = UPPER(?)
+var caseInsensitivePattern = regexp.MustCompile("(?i)^\\s*(?:LOWER|UPPER)\\(\\s*([\\w.\"`]+)\\s*\\)\\s*=\\s*(?:LOWER|UPPER)\\(\\s*\\?\\s*\\)\\s*$")
+
+// caseInsensitiveEq turns a case-insensitive condition of gormcase back into the condition it was made from, returns
+// false if the expression is not one of them
+func caseInsensitiveEq(expr clause.Expr) (clause.Eq, bool) {
+	if len(expr.Vars) != 1 {
+		return clause.Eq{}, false
+	}
+
+	match := caseInsensitivePattern.FindStringSubmatch(expr.SQL)
+	if match == nil {
+		return clause.Eq{}, false
+	}
+
+	column := clause.Column{Table: clause.CurrentTable, Name: strings.NewReplacer(`"`, "", "`", "").Replace(match[1])}
+
+	if table, name, ok := strings.Cut(column.Name, "."); ok {
+		column = clause.Column{Table: table, Name: name}
+	}
+
+	return clause.Eq{Column: column, Value: expr.Vars[0]}, true
+}
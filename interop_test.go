@@ -0,0 +1,330 @@
+package gormlike
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+type ObjectU struct {
+	ID      int
+	Name    string
+	Secret  string `gormlike:"false"`
+	Objects []ObjectV
+}
+
+type ObjectV struct {
+	ID        int
+	ObjectUID int
+	Code      string
+}
+
+// caseInsensitiveCallback turns equality conditions into case-insensitive ones, like gormcase does
+func caseInsensitiveCallback(db *gorm.DB) {
+	where, ok := db.Statement.Clauses["WHERE"].Expression.(clause.Where)
+	if !ok {
+		return
+	}
+
+	where.Exprs = slices.Clone(where.Exprs)
+
+	for index, expression := range where.Exprs {
+		eq, ok := expression.(clause.Eq)
+		if !ok {
+			continue
+		}
+
+		// gormcase builds the condition on a new statement and uses its WHERE clause in place of the condition
+		column, _ := eq.Column.(clause.Column)
+		condition := fmt.Sprintf("LOWER(%s) = LOWER(?)", column.Name)
+		where.Exprs[index] = db.Session(&gorm.Session{NewDB: true}).Where(condition, eq.Value).Statement.Clauses["WHERE"].Expression
+	}
+
+	whereClause := db.Statement.Clauses["WHERE"]
+	whereClause.Expression = where
+	db.Statement.Clauses["WHERE"] = whereClause
+}
+
+// deepFilterCallback turns nested maps on has-many associations into subqueries, like deepgorm does
+func deepFilterCallback(db *gorm.DB) {
+	where, ok := db.Statement.Clauses["WHERE"].Expression.(clause.Where)
+	if !ok || db.Statement.Schema == nil {
+		return
+	}
+
+	where.Exprs = slices.Clone(where.Exprs)
+
+	for index, expression := range where.Exprs {
+		eq, ok := expression.(clause.Eq)
+		if !ok {
+			continue
+		}
+
+		column, _ := eq.Column.(clause.Column)
+		filter, isMap := eq.Value.(map[string]any)
+		if !isMap {
+			continue
+		}
+
+		var relationship *schema.Relationship
+
+		for _, candidate := range db.Statement.Schema.Relationships.HasMany {
+			if db.NamingStrategy.ColumnName("", candidate.Name) == column.Name {
+				relationship = candidate
+			}
+		}
+
+		if relationship == nil {
+			continue
+		}
+
+		// deepgorm builds the filter on a new statement and uses its WHERE clause in place of the condition
+		subquery := db.Session(&gorm.Session{NewDB: true}).
+			Model(reflect.New(relationship.FieldSchema.ModelType).Interface()).
+			Select(relationship.References[0].ForeignKey.DBName).
+			Where(filter)
+
+		where.Exprs[index] = db.Session(&gorm.Session{NewDB: true}).Where("id IN (?)", subquery).Statement.Clauses["WHERE"].Expression
+	}
+
+	whereClause := db.Statement.Clauses["WHERE"]
+	whereClause.Expression = where
+	db.Statement.Clauses["WHERE"] = whereClause
+}
+
+func TestGormLike_Initialize_WorksWithDeepGorm(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options []Option
+	}{
+		"after deepgorm": {
+			options: []Option{RunAfter("deepgorm:query")},
+		},
+		"before deepgorm": {
+			options: []Option{RunBefore("deepgorm:query")},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			require.NoError(t, db.Callback().Query().Before("gorm:query").Register("deepgorm:query", deepFilterCallback))
+
+			// Act
+			err := db.Use(New(testData.options...))
+
+			// Assert
+			require.NoError(t, err)
+
+			var actual []ObjectU
+			query := db.Session(&gorm.Session{DryRun: true}).
+				Where(map[string]any{"name": "J%"}).
+				Where(map[string]any{"objects": map[string]any{"code": "%X"}}).
+				Find(&actual)
+			require.NoError(t, query.Error)
+
+			expected := "SELECT * FROM `object_us` WHERE CAST(`object_us`.`name` as varchar) LIKE ? AND id IN (SELECT `object_uid` FROM `object_vs` WHERE CAST(`object_vs`.`code` as varchar) LIKE ?)"
+			assert.Equal(t, expected, query.Statement.SQL.String())
+			assert.Equal(t, []any{"J%", "%X"}, query.Statement.Vars)
+		})
+	}
+}
+
+func TestGormLike_Initialize_WorksWithOtherPlugins(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options []Option
+		query   func(*gorm.DB) *gorm.DB
+
+		expected     string
+		expectedVars []any
+	}{
+		"after gormcase": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(map[string]any{"name": "J%"})
+			},
+			expected:     "SELECT * FROM `object_us` WHERE LOWER(CAST(`object_us`.`name` as varchar)) LIKE LOWER(?)",
+			expectedVars: []any{"J%"},
+		},
+		"after gormcase, tokenised": {
+			options: []Option{Tokenised()},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Set("gormlike:tokenise", true).Where(map[string]any{"name": "J"})
+			},
+			expected:     "SELECT * FROM `object_us` WHERE LOWER(CAST(`object_us`.`name` as varchar)) LIKE LOWER(?)",
+			expectedVars: []any{"%J%"},
+		},
+		"after gormcase, no wildcard": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(map[string]any{"name": "J"})
+			},
+			expected:     "SELECT * FROM `object_us` WHERE LOWER(name) = LOWER(?)",
+			expectedVars: []any{"J"},
+		},
+		"after gormcase, not likeable": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(map[string]any{"secret": "J%"})
+			},
+			expected:     "SELECT * FROM `object_us` WHERE LOWER(secret) = LOWER(?)",
+			expectedVars: []any{"J%"},
+		},
+		"before gormcase": {
+			options: []Option{RunBefore("gormcase:query")},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(map[string]any{"name": "J%"})
+			},
+			expected:     "SELECT * FROM `object_us` WHERE CAST(`object_us`.`name` as varchar) LIKE ?",
+			expectedVars: []any{"J%"},
+		},
+		"subquery after gormcase": {
+			options: []Option{RunAfter("gormcase:query")},
+			query: func(db *gorm.DB) *gorm.DB {
+				subquery := db.Model(&ObjectV{}).Select("object_u_id").Where(map[string]any{"code": "%X"})
+
+				return db.Where("id IN (?)", subquery)
+			},
+			expected:     "SELECT * FROM `object_us` WHERE id IN (SELECT object_u_id FROM `object_vs` WHERE LOWER(CAST(`object_vs`.`code` as varchar)) LIKE LOWER(?))",
+			expectedVars: []any{"%X"},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			require.NoError(t, db.Callback().Query().Before("gorm:query").Register("gormcase:query", caseInsensitiveCallback))
+
+			// Act
+			err := db.Use(New(testData.options...))
+
+			// Assert
+			require.NoError(t, err)
+
+			var actual []ObjectU
+			query := testData.query(db.Session(&gorm.Session{DryRun: true})).Find(&actual)
+			require.NoError(t, query.Error)
+
+			assert.Equal(t, testData.expected, query.Statement.SQL.String())
+			assert.Equal(t, testData.expectedVars, query.Statement.Vars)
+		})
+	}
+}
+
+func TestGormLike_Initialize_RegistersRowCallbackIfOtherPluginOnlyQueries(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectU{})
+	require.NoError(t, db.Create(&[]ObjectU{{ID: 1, Name: "jessica"}, {ID: 2, Name: "amy"}}).Error)
+	require.NoError(t, db.Callback().Query().Before("gorm:query").Register("gormcase:query", caseInsensitiveCallback))
+
+	// Act
+	err := db.Use(New(RunBefore("gormcase:query")))
+
+	// Assert
+	require.NoError(t, err)
+
+	var count int64
+	require.NoError(t, db.Model(&ObjectU{}).Where(map[string]any{"name": "j%"}).Select("COUNT(*)").Row().Scan(&count))
+	assert.Equal(t, int64(1), count)
+}
+
+func TestGormLike_Initialize_ReturnsErrorOnUnknownCallback(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options []Option
+	}{
+		"run before": {
+			options: []Option{RunBefore("gormcase:query")},
+		},
+		"run after": {
+			options: []Option{RunAfter("deepgorm:query")},
+		},
+		"observe before": {
+			options: []Option{WithObserver(&MemoryObserver{}), ObserveBefore("otel:after_query")},
+		},
+		"observe after": {
+			options: []Option{WithObserver(&MemoryObserver{}), ObserveAfter("otel:after_query")},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+			// Act
+			err := db.Use(New(testData.options...))
+
+			// Assert
+			require.ErrorIs(t, err, ErrUnknownCallback)
+			assert.Nil(t, db.Callback().Query().Get("gormlike:query"))
+		})
+	}
+}
+
+func TestCaseInsensitiveEq_ReturnsExpectedCondition(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		expr clause.Expr
+
+		expected   clause.Eq
+		expectedOk bool
+	}{
+		"lower": {
+			expr:       clause.Expr{SQL: "LOWER(name) = LOWER(?)", Vars: []any{"a%"}},
+			expected:   clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "name"}, Value: "a%"},
+			expectedOk: true,
+		},
+		"upper with quoted table": {
+			expr:       clause.Expr{SQL: `upper("users"."name") = UPPER( ? )`, Vars: []any{"a%"}},
+			expected:   clause.Eq{Column: clause.Column{Table: "users", Name: "name"}, Value: "a%"},
+			expectedOk: true,
+		},
+		"backticks": {
+			expr:       clause.Expr{SQL: "LOWER(`name`) = LOWER(?)", Vars: []any{"a%"}},
+			expected:   clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "name"}, Value: "a%"},
+			expectedOk: true,
+		},
+		"other condition": {
+			expr: clause.Expr{SQL: "name = ?", Vars: []any{"a%"}},
+		},
+		"other function": {
+			expr: clause.Expr{SQL: "TRIM(name) = LOWER(?)", Vars: []any{"a%"}},
+		},
+		"combined conditions": {
+			expr: clause.Expr{SQL: "LOWER(name) = LOWER(?) OR 1 = 1", Vars: []any{"a%"}},
+		},
+		"no vars": {
+			expr: clause.Expr{SQL: "LOWER(name) = LOWER(?)"},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, ok := caseInsensitiveEq(testData.expr)
+
+			// Assert
+			assert.Equal(t, testData.expectedOk, ok)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
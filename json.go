@@ -0,0 +1,94 @@
+package gormlike
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// jsonKeyPattern restricts the keys in a JSON path, as they end up in the query
+var jsonKeyPattern = regexp.MustCompile(`^\w+$`)
+
+// isJSONField returns whether the field is stored as JSON, using either a json(b) type or the json serializer
+func isJSONField(field *schema.Field) bool {
+	return isJSONTyped(field) ||
+		strings.Contains(strings.ToLower(string(field.GORMDataType)), "json") ||
+		strings.EqualFold(field.TagSettings["SERIALIZER"], "json")
+}
+
+// isJSONTyped returns whether the column of the field has a json(b) type, instead of text containing JSON
+func isJSONTyped(field *schema.Field) bool {
+	return strings.Contains(strings.ToLower(string(field.DataType)), "json")
+}
+
+// jsonExtract returns the SQL that extracts the value at path from the JSON column of the field as text, returns
+// false if the dialect is not supported
+func jsonExtract(db *gorm.DB, field *schema.Field, table string, path []string) (string, bool) {
+	column := quoteColumn(db, table, field.DBName)
+
+	switch db.Dialector.Name() {
+	case "postgres":
+		// metadata->'customer'->>'name', the json serializer stores text that has to be cast first
+		var result strings.Builder
+
+		if isJSONTyped(field) {
+			result.WriteString(column)
+		} else {
+			result.WriteString("CAST(" + column + " AS jsonb)")
+		}
+
+		for index, key := range path {
+			if index == len(path)-1 {
+				result.WriteString("->>")
+			} else {
+				result.WriteString("->")
+			}
+
+			result.WriteString("'" + key + "'")
+		}
+
+		return result.String(), true
+	case "mysql":
+		return fmt.Sprintf("%s->>'$.%s'", column, strings.Join(path, ".")), true
+	case "sqlite":
+		return fmt.Sprintf("JSON_EXTRACT(%s, '$.%s')", column, strings.Join(path, ".")), true
+	default:
+		return "", false
+	}
+}
+
+// resolveJSONTarget turns a column like `metadata.customer.name` into a target on the `customer.name` path of
+// the JSON column `metadata`, the tags of `metadata` decide whether it is likeable
+func resolveJSONTarget(db *gorm.DB, schemaValue *schema.Schema, table, name string) (target, bool) {
+	path := strings.Split(name, ".")
+
+	dbField, ok := schemaValue.FieldsByDBName[path[0]]
+	if !ok || !isJSONField(dbField) {
+		return target{}, false
+	}
+
+	for _, key := range path[1:] {
+		if !jsonKeyPattern.MatchString(key) {
+			return target{}, false
+		}
+	}
+
+	extracted, ok := jsonExtract(db, dbField, table, path[1:])
+	if !ok {
+		return target{}, false
+	}
+
+	result := target{
+		nested: true,
+		field:  dbField,
+		condition: func(how comparison) string {
+			// The extracted value is already text, so no need to CAST it
+			return compare(extracted, how)
+		},
+	}
+
+	return result, true
+}
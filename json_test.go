@@ -0,0 +1,228 @@
+package gormlike
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestGormLike_Initialize_TriggersLikingOnJSONPathsCorrectly(t *testing.T) {
+	t.Parallel()
+
+	type ObjectD struct {
+		ID       int
+		Metadata string `gorm:"type:json"`
+		Other    string `gorm:"type:json" gormlike:"false"`
+		Name     string
+	}
+
+	acme := ObjectD{ID: 1, Metadata: `{"customer": {"name": "Acme BV", "tier": 3}}`, Other: `{"a": "bc"}`, Name: "a.b"}
+	initech := ObjectD{ID: 2, Metadata: `{"customer": {"name": "Initech", "tier": 1}}`, Other: `{"a": "bc"}`, Name: "a.b"}
+	globex := ObjectD{ID: 3, Metadata: `{"customer": {"name": "Globex BV"}, "region": "EU"}`, Other: `{"a": "de"}`}
+
+	tests := map[string]struct {
+		filter   map[string]any
+		options  []Option
+		expected []ObjectD
+	}{
+		"nested path": {
+			filter:   map[string]any{"metadata.customer.name": "%BV"},
+			expected: []ObjectD{acme, globex},
+		},
+		"top-level path": {
+			filter:   map[string]any{"metadata.region": "E%"},
+			expected: []ObjectD{globex},
+		},
+		"numeric value": {
+			filter:   map[string]any{"metadata.customer.tier": "%3%"},
+			expected: []ObjectD{acme},
+		},
+		"multi-value path": {
+			filter:   map[string]any{"metadata.customer.name": []string{"Init%", "Glo%"}},
+			expected: []ObjectD{initech, globex},
+		},
+		"multi-value path with some like values": {
+			filter:   map[string]any{"metadata.customer.name": []string{"Initech", "Glo%"}},
+			expected: []ObjectD{initech, globex},
+		},
+		"exact value": {
+			filter:   map[string]any{"metadata.customer.name": "Acme BV"},
+			expected: []ObjectD{acme},
+		},
+		"exact values": {
+			filter:   map[string]any{"metadata.customer.name": []string{"Initech", "Acme BV"}},
+			expected: []ObjectD{acme, initech},
+		},
+		"exact value on a field that isn't likeable": {
+			filter:   map[string]any{"other.a": "de"},
+			expected: []ObjectD{globex},
+		},
+		"with custom character": {
+			filter:   map[string]any{"metadata.customer.name": "🍌BV"},
+			options:  []Option{WithCharacter("🍌")},
+			expected: []ObjectD{acme, globex},
+		},
+		"combined with normal columns": {
+			filter:   map[string]any{"metadata.customer.name": "%BV", "name": "a.b"},
+			expected: []ObjectD{acme},
+		},
+		"tagged only ignores untagged json fields": {
+			filter:   map[string]any{"metadata.customer.name": "%BV"},
+			options:  []Option{TaggedOnly()},
+			expected: nil,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectD{})
+			plugin := New(testData.options...)
+
+			if err := db.CreateInBatches([]ObjectD{acme, initech, globex}, 10).Error; err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+
+			// Act
+			err := db.Use(plugin)
+
+			// Assert
+			require.NoError(t, err)
+
+			var actual []ObjectD
+			err = db.Where(testData.filter).Find(&actual).Error
+
+			// Without the plugin the path is treated as a table name
+			if testData.expected == nil {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
+
+func TestGormLike_Initialize_IgnoresInvalidJSONPaths(t *testing.T) {
+	t.Parallel()
+
+	type ObjectD struct {
+		ID       int
+		Metadata string `gorm:"type:json"`
+		Other    string `gorm:"type:json" gormlike:"false"`
+		Name     string
+	}
+
+	tests := map[string]struct {
+		filter map[string]any
+	}{
+		"not a json field": {
+			filter: map[string]any{"name.customer": "%BV"},
+		},
+		"json field disabled with tag": {
+			filter: map[string]any{"other.a": "%c"},
+		},
+		"unknown field": {
+			filter: map[string]any{"unknown.a": "%c"},
+		},
+		"invalid key": {
+			filter: map[string]any{"metadata.customer'); DROP TABLE object_ds; --": "%c"},
+		},
+		"empty key": {
+			filter: map[string]any{"metadata..name": "%c"},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectD{})
+
+			// Act
+			err := db.Use(New())
+
+			// Assert
+			require.NoError(t, err)
+
+			var actual []ObjectD
+			query := db.Session(&gorm.Session{DryRun: true}).Where(testData.filter).Find(&actual)
+			require.NoError(t, query.Error)
+
+			assert.NotContains(t, query.Statement.SQL.String(), "LIKE")
+		})
+	}
+}
+
+func TestGormLike_Initialize_GeneratesDialectSpecificJSONQueries(t *testing.T) {
+	t.Parallel()
+
+	type ObjectD struct {
+		Metadata string `gorm:"serializer:json"`
+		Settings string `gorm:"type:jsonb"`
+	}
+
+	tests := map[string]struct {
+		dialect  string
+		key      string
+		expected string
+	}{
+		"postgres": {
+			dialect:  "postgres",
+			expected: "SELECT * FROM `object_ds` WHERE CAST(`object_ds`.`metadata` AS jsonb)->'customer'->>'name' LIKE ?",
+		},
+		"postgres with jsonb column": {
+			dialect:  "postgres",
+			key:      "settings.customer.name",
+			expected: "SELECT * FROM `object_ds` WHERE `object_ds`.`settings`->'customer'->>'name' LIKE ?",
+		},
+		"mysql": {
+			dialect:  "mysql",
+			expected: "SELECT * FROM `object_ds` WHERE `object_ds`.`metadata`->>'$.customer.name' LIKE ?",
+		},
+		"sqlite": {
+			dialect:  "sqlite",
+			expected: "SELECT * FROM `object_ds` WHERE JSON_EXTRACT(`object_ds`.`metadata`, '$.customer.name') LIKE ?",
+		},
+		"unsupported": {
+			dialect:  "sqlserver",
+			expected: "SELECT * FROM `object_ds` WHERE `object_ds`.`metadata`.`customer`.`name` = ?",
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			db.Dialector = namedDialector{Dialector: db.Dialector, name: testData.dialect}
+
+			key := testData.key
+			if key == "" {
+				key = "metadata.customer.name"
+			}
+
+			// Act
+			err := db.Use(New())
+
+			// Assert
+			require.NoError(t, err)
+
+			var actual []ObjectD
+			query := db.Session(&gorm.Session{DryRun: true}).Where(map[string]any{key: "%bv"}).Find(&actual)
+			require.NoError(t, query.Error)
+
+			assert.Equal(t, testData.expected, query.Statement.SQL.String())
+			assert.Equal(t, []any{"%bv"}, query.Statement.Vars)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package gormlike
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Compile-time interface checks
+var (
+	_ clause.Expression                = Like{}
+	_ clause.NegationExpressionBuilder = Like{}
+	_ clause.Expression                = NotLike{}
+	_ clause.NegationExpressionBuilder = NotLike{}
+)
+
+// Like is a LIKE condition that can be used without the plugin, e.g. db.Where(gormlike.Like{Column: "name", Value: "jo%"}).
+// The column is cast to text first, so that other types like UUIDs can be queried as well, except for string fields on
+// MySQL, like the plugin does. It is always rendered as a LIKE condition, regardless of wildcards in the value or the
+// options of the plugin.
+type Like struct {
+	// Column is either the name of a column or a clause.Column
+	Column any
+
+	// Value is the pattern to match, wildcards are not replaced
+	Value any
+}
+
+// Build writes the condition in the dialect of the builder
+func (like Like) Build(builder clause.Builder) {
+	buildLike(builder, like.Column, like.Value, "LIKE")
+}
+
+// NegationBuild writes the negated condition, used in db.Not(...)
+func (like Like) NegationBuild(builder clause.Builder) {
+	buildLike(builder, like.Column, like.Value, "NOT LIKE")
+}
+
+// NotLike is the negated version of Like, e.g. db.Where(gormlike.NotLike{Column: "name", Value: "jo%"})
+type NotLike Like
+
+// Build writes the condition in the dialect of the builder
+func (notLike NotLike) Build(builder clause.Builder) {
+	buildLike(builder, notLike.Column, notLike.Value, "NOT LIKE")
+}
+
+// NegationBuild writes the negated condition, used in db.Not(...)
+func (notLike NotLike) NegationBuild(builder clause.Builder) {
+	buildLike(builder, notLike.Column, notLike.Value, "LIKE")
+}
+
+// buildLike writes a condition like CAST(column as varchar) LIKE ? in the dialect of the builder. Columns of the
+// statement's model are matched the same way as the plugin does, so they can use the indexes created by Migrate().
+func buildLike(builder clause.Builder, column, value any, operator string) {
+	statement, ok := builder.(*gorm.Statement)
+	if !ok {
+		builder.WriteString("CAST(")
+		builder.WriteQuoted(column)
+		builder.WriteString(" as " + textType("") + ") " + operator + " ")
+		builder.AddVar(builder, value)
+
+		return
+	}
+
+	builder.WriteString(likeExpression(statement.Dialector.Name(), statement.Quote(column), statementField(statement, column)))
+	builder.WriteString(" " + operator + " ")
+	builder.AddVar(builder, value)
+}
+
+// statementField returns the field of the statement's model that the column refers to, nil if it's not one
+func statementField(statement *gorm.Statement, column any) *schema.Field {
+	if statement.Schema == nil {
+		return nil
+	}
+
+	switch column := column.(type) {
+	case string:
+		return statement.Schema.LookUpField(column)
+	case clause.Column:
+		if column.Table == "" || column.Table == clause.CurrentTable || column.Table == statement.Table {
+			return statement.Schema.LookUpField(column.Name)
+		}
+	}
+
+	return nil
+}
+
+// textType returns the type that columns are cast to in a LIKE condition, as not every database supports varchar
+func textType(dialect string) string {
+	switch dialect {
+	case "mysql":
+		return "char"
+	case "sqlserver":
+		return "nvarchar(max)"
+	default:
+		return "varchar"
+	}
+}
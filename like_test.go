@@ -0,0 +1,202 @@
+package gormlike
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestLike_Build_GeneratesDialectSpecificQueries(t *testing.T) {
+	t.Parallel()
+
+	type ObjectF struct {
+		Name string
+		Age  int
+	}
+
+	tests := map[string]struct {
+		dialect  string
+		like     clause.Expression
+		expected string
+	}{
+		"postgres": {
+			dialect:  "postgres",
+			like:     Like{Column: "name", Value: "jo%"},
+			expected: "SELECT * FROM `object_fs` WHERE CAST(`name` as varchar) LIKE ?",
+		},
+		"mysql": {
+			dialect:  "mysql",
+			like:     Like{Column: "name", Value: "jo%"},
+			expected: "SELECT * FROM `object_fs` WHERE `name` LIKE ?",
+		},
+		"mysql with field that isn't a string": {
+			dialect:  "mysql",
+			like:     Like{Column: "age", Value: "jo%"},
+			expected: "SELECT * FROM `object_fs` WHERE CAST(`age` as char) LIKE ?",
+		},
+		"mysql with unknown column": {
+			dialect:  "mysql",
+			like:     Like{Column: "nickname", Value: "jo%"},
+			expected: "SELECT * FROM `object_fs` WHERE CAST(`nickname` as char) LIKE ?",
+		},
+		"mysql with column of current table": {
+			dialect:  "mysql",
+			like:     Like{Column: clause.Column{Table: clause.CurrentTable, Name: "name"}, Value: "jo%"},
+			expected: "SELECT * FROM `object_fs` WHERE `object_fs`.`name` LIKE ?",
+		},
+		"sqlserver": {
+			dialect:  "sqlserver",
+			like:     Like{Column: "name", Value: "jo%"},
+			expected: "SELECT * FROM `object_fs` WHERE CAST(`name` as nvarchar(max)) LIKE ?",
+		},
+		"sqlite": {
+			dialect:  "sqlite",
+			like:     Like{Column: "name", Value: "jo%"},
+			expected: "SELECT * FROM `object_fs` WHERE CAST(`name` as varchar) LIKE ?",
+		},
+		"not like": {
+			dialect:  "postgres",
+			like:     NotLike{Column: "name", Value: "jo%"},
+			expected: "SELECT * FROM `object_fs` WHERE CAST(`name` as varchar) NOT LIKE ?",
+		},
+		"negated like": {
+			dialect:  "postgres",
+			like:     clause.Not(Like{Column: "name", Value: "jo%"}),
+			expected: "SELECT * FROM `object_fs` WHERE CAST(`name` as varchar) NOT LIKE ?",
+		},
+		"negated not like": {
+			dialect:  "mysql",
+			like:     clause.Not(NotLike{Column: "name", Value: "jo%"}),
+			expected: "SELECT * FROM `object_fs` WHERE `name` LIKE ?",
+		},
+		"column of current table": {
+			dialect:  "sqlite",
+			like:     Like{Column: clause.Column{Table: clause.CurrentTable, Name: "name"}, Value: "jo%"},
+			expected: "SELECT * FROM `object_fs` WHERE CAST(`object_fs`.`name` as varchar) LIKE ?",
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			db.Dialector = namedDialector{Dialector: db.Dialector, name: testData.dialect}
+
+			var actual []ObjectF
+
+			// Act
+			query := db.Session(&gorm.Session{DryRun: true}).Where(testData.like).Find(&actual)
+
+			// Assert
+			require.NoError(t, query.Error)
+
+			assert.Equal(t, testData.expected, query.Statement.SQL.String())
+			assert.Equal(t, []any{"jo%"}, query.Statement.Vars)
+		})
+	}
+}
+
+func TestLike_Build_AlwaysLikesRegardlessOfPlugin(t *testing.T) {
+	t.Parallel()
+
+	type ObjectF struct {
+		ID    int
+		Name  string
+		Other string `gormlike:"false"`
+	}
+
+	john := ObjectF{ID: 1, Name: "john", Other: "a%c"}
+	jane := ObjectF{ID: 2, Name: "jane", Other: "abc"}
+	amy := ObjectF{ID: 3, Name: "amy", Other: "def"}
+
+	tests := map[string]struct {
+		query    func(*gorm.DB) *gorm.DB
+		plugin   bool
+		options  []Option
+		expected []ObjectF
+	}{
+		"like without plugin": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(Like{Column: "name", Value: "%n%"}).Or(Like{Column: "id", Value: "3"})
+			},
+			expected: []ObjectF{john, jane, amy},
+		},
+		"not like without plugin": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(NotLike{Column: "name", Value: "j%"})
+			},
+			expected: []ObjectF{amy},
+		},
+		"negated like without plugin": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Not(Like{Column: "name", Value: "j%"})
+			},
+			expected: []ObjectF{amy},
+		},
+		"single character wildcard without percentage sign": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(Like{Column: "name", Value: "j_n_"})
+			},
+			plugin:   true,
+			expected: []ObjectF{jane},
+		},
+		"disallowed field with plugin": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(Like{Column: "other", Value: "a%"})
+			},
+			plugin:   true,
+			expected: []ObjectF{john, jane},
+		},
+		"tagged only plugin": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(Like{Column: "name", Value: "j%"})
+			},
+			plugin:   true,
+			options:  []Option{TaggedOnly()},
+			expected: []ObjectF{john, jane},
+		},
+		"setting disabled": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Set(tagName, false).Where(Like{Column: "name", Value: "j%"})
+			},
+			plugin:   true,
+			expected: []ObjectF{john, jane},
+		},
+		"custom character is not replaced": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(Like{Column: "other", Value: "a%c"})
+			},
+			plugin:   true,
+			options:  []Option{WithCharacter("%")},
+			expected: []ObjectF{john, jane},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectF{})
+			require.NoError(t, db.Create([]ObjectF{john, jane, amy}).Error)
+
+			if testData.plugin {
+				require.NoError(t, db.Use(New(testData.options...)))
+			}
+
+			var actual []ObjectF
+
+			// Act
+			err := testData.query(db).Find(&actual).Error
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
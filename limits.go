@@ -0,0 +1,125 @@
+package gormlike
+
+import (
+	"unicode/utf8"
+)
+
+// Limits protects the database against patterns that are expensive to match, like %a%a%a%a%a%, which is useful when
+// clients are allowed to search using wildcards. A limit of zero means that there is no limit.
+type Limits struct {
+	// PatternLength is the maximum number of characters of a pattern
+	PatternLength int
+
+	// Wildcards is the maximum number of wildcards in a pattern, consecutive wildcards count as one
+	Wildcards int
+
+	// Terms is the maximum number of LIKE conditions made from a single clause.IN list or tokenised value
+	Terms int
+
+	// Truncate shortens patterns that exceed the limits instead of leaving the condition alone, truncated patterns
+	// match more rows than the original, e.g. %a%b%c% becomes %a% with a limit of 2 wildcards. Tokenised values only
+	// keep the first tokens. IN lists are never truncated, as leaving out values would match fewer rows.
+	Truncate bool
+}
+
+// limitPattern applies the limits to the pattern, returns the reason if the pattern exceeds them and isn't truncated
+func (d *gormLike) limitPattern(pattern string) (string, string) {
+	limits := d.limits
+
+	if limits.PatternLength > 0 && utf8.RuneCountInString(pattern) > limits.PatternLength {
+		if !limits.Truncate {
+			return "", ReasonPatternTooLong
+		}
+
+		pattern = truncateLength(pattern, limits.PatternLength)
+	}
+
+	// Truncating the length might add a wildcard, so the wildcards are checked afterwards
+	if limits.Wildcards > 0 && countWildcards(pattern) > limits.Wildcards {
+		if !limits.Truncate {
+			return "", ReasonTooManyWildcards
+		}
+
+		pattern = truncateWildcards(pattern, limits.Wildcards)
+	}
+
+	return pattern, ""
+}
+
+// limitPatterns applies the limits to all patterns of a single value, returns the reason if any of them exceeds the
+// limits and isn't truncated. canTruncateTerms allows leaving out the last patterns if there are too many.
+func (d *gormLike) limitPatterns(patterns []string, canTruncateTerms bool) ([]string, string) {
+	if d.limits.Terms > 0 && len(patterns) > d.limits.Terms {
+		if !d.limits.Truncate || !canTruncateTerms {
+			return nil, ReasonTooManyTerms
+		}
+
+		patterns = patterns[:d.limits.Terms]
+	}
+
+	result := make([]string, len(patterns))
+
+	for index, pattern := range patterns {
+		limited, reason := d.limitPattern(pattern)
+		if reason != "" {
+			return nil, reason
+		}
+
+		result[index] = limited
+	}
+
+	return result, ""
+}
+
+// countWildcards returns the number of % wildcards in the pattern, consecutive wildcards count as one
+func countWildcards(pattern string) int {
+	var result int
+
+	var previous rune
+
+	for _, character := range pattern {
+		if character == '%' && previous != '%' {
+			result++
+		}
+
+		previous = character
+	}
+
+	return result
+}
+
+// truncateLength shortens the pattern to the given number of characters, ending with a wildcard so that it matches
+// everything the original pattern matched
+func truncateLength(pattern string, length int) string {
+	characters := []rune(pattern)
+
+	// The pattern already ends with a wildcard after truncating
+	if characters[length-1] == '%' {
+		return string(characters[:length])
+	}
+
+	return string(characters[:length-1]) + "%"
+}
+
+// truncateWildcards cuts off the pattern after the given number of wildcards, so that it matches everything the original
+// pattern matched
+func truncateWildcards(pattern string, wildcards int) string {
+	var count int
+
+	var previous rune
+
+	for index, character := range pattern {
+		if character == '%' && previous != '%' {
+			count++
+		}
+
+		// The pattern is cut off once the last allowed wildcard is followed by something else
+		if count == wildcards && previous == '%' && character != '%' {
+			return pattern[:index]
+		}
+
+		previous = character
+	}
+
+	return pattern
+}
@@ -0,0 +1,181 @@
+package gormlike
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type ObjectY struct {
+	ID   int
+	Name string
+}
+
+func TestGormLike_Initialize_AppliesLimits(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		query   func(*gorm.DB) *gorm.DB
+		options []Option
+
+		expected     string
+		expectedVars []any
+		expectedErr  error
+	}{
+		"pattern within limits": {
+			query:        func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "%a%b%"}) },
+			options:      []Option{WithLimits(Limits{PatternLength: 5, Wildcards: 3})},
+			expected:     "SELECT * FROM `object_ies` WHERE CAST(`object_ies`.`name` as varchar) LIKE ?",
+			expectedVars: []any{"%a%b%"},
+		},
+		"pattern too long": {
+			query:        func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "abcdef%"}) },
+			options:      []Option{WithLimits(Limits{PatternLength: 5})},
+			expected:     "SELECT * FROM `object_ies` WHERE `object_ies`.`name` = ?",
+			expectedVars: []any{"abcdef%"},
+		},
+		"pattern too long in strict mode": {
+			query:       func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "abcdef%"}) },
+			options:     []Option{WithLimits(Limits{PatternLength: 5}), Strict()},
+			expectedErr: ErrPatternTooLong,
+		},
+		"pattern too long is truncated": {
+			query:        func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "%abcdef"}) },
+			options:      []Option{WithLimits(Limits{PatternLength: 5, Truncate: true})},
+			expected:     "SELECT * FROM `object_ies` WHERE CAST(`object_ies`.`name` as varchar) LIKE ?",
+			expectedVars: []any{"%abc%"},
+		},
+		"too many wildcards": {
+			query:        func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "%a%a%a%a%"}) },
+			options:      []Option{WithLimits(Limits{Wildcards: 4})},
+			expected:     "SELECT * FROM `object_ies` WHERE `object_ies`.`name` = ?",
+			expectedVars: []any{"%a%a%a%a%"},
+		},
+		"consecutive wildcards count as one": {
+			query:        func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "%%a%%"}) },
+			options:      []Option{WithLimits(Limits{Wildcards: 2})},
+			expected:     "SELECT * FROM `object_ies` WHERE CAST(`object_ies`.`name` as varchar) LIKE ?",
+			expectedVars: []any{"%%a%%"},
+		},
+		"too many wildcards in strict mode": {
+			query:       func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "%a%a%a%a%"}) },
+			options:     []Option{WithLimits(Limits{Wildcards: 4}), Strict()},
+			expectedErr: ErrTooManyWildcards,
+		},
+		"too many wildcards is truncated": {
+			query:        func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "%a%b%c%"}) },
+			options:      []Option{WithLimits(Limits{Wildcards: 2, Truncate: true})},
+			expected:     "SELECT * FROM `object_ies` WHERE CAST(`object_ies`.`name` as varchar) LIKE ?",
+			expectedVars: []any{"%a%"},
+		},
+		"too many terms in IN": {
+			query:        func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": []string{"a%", "b%", "c%"}}) },
+			options:      []Option{WithLimits(Limits{Terms: 2})},
+			expected:     "SELECT * FROM `object_ies` WHERE `object_ies`.`name` IN (?,?,?)",
+			expectedVars: []any{"a%", "b%", "c%"},
+		},
+		"too many terms in IN are not truncated": {
+			query:       func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": []string{"a%", "b%", "c%"}}) },
+			options:     []Option{WithLimits(Limits{Terms: 2, Truncate: true}), Strict()},
+			expectedErr: ErrTooManyTerms,
+		},
+		"values without wildcards in IN are not terms": {
+			query:        func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": []string{"a%", "b", "c"}}) },
+			options:      []Option{WithLimits(Limits{Terms: 1})},
+			expected:     "SELECT * FROM `object_ies` WHERE (CAST(`object_ies`.`name` as varchar) LIKE ? OR `object_ies`.`name` = ? OR `object_ies`.`name` = ?)",
+			expectedVars: []any{"a%", "b", "c"},
+		},
+		"pattern in IN is truncated": {
+			query:        func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": []string{"a", "%a%b%"}}) },
+			options:      []Option{WithLimits(Limits{Wildcards: 1, Truncate: true})},
+			expected:     "SELECT * FROM `object_ies` WHERE (`object_ies`.`name` = ? OR CAST(`object_ies`.`name` as varchar) LIKE ?)",
+			expectedVars: []any{"a", "%"},
+		},
+		"too many tokens": {
+			query:        func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "john amsterdam"}) },
+			options:      []Option{Tokenised(), WithTokenColumns("name"), WithLimits(Limits{Terms: 1})},
+			expected:     "SELECT * FROM `object_ies` WHERE `object_ies`.`name` = ?",
+			expectedVars: []any{"john amsterdam"},
+		},
+		"too many tokens are truncated": {
+			query:        func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "john amsterdam"}) },
+			options:      []Option{Tokenised(), WithTokenColumns("name"), WithLimits(Limits{Terms: 1, Truncate: true})},
+			expected:     "SELECT * FROM `object_ies` WHERE CAST(`object_ies`.`name` as varchar) LIKE ?",
+			expectedVars: []any{"%john%"},
+		},
+		"not like with too many wildcards": {
+			query:        func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "!%a%b%"}) },
+			options:      []Option{WithOperators(), WithLimits(Limits{Wildcards: 2})},
+			expected:     "SELECT * FROM `object_ies` WHERE `object_ies`.`name` = ?",
+			expectedVars: []any{"!%a%b%"},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+			// Act
+			err := db.Use(New(testData.options...))
+
+			// Assert
+			require.NoError(t, err)
+
+			var actual []ObjectY
+			query := testData.query(db.Session(&gorm.Session{DryRun: true})).Find(&actual)
+
+			if testData.expectedErr != nil {
+				require.ErrorIs(t, query.Error, testData.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, query.Error)
+			assert.Equal(t, testData.expected, query.Statement.SQL.String())
+			assert.Equal(t, testData.expectedVars, query.Statement.Vars)
+		})
+	}
+}
+
+func TestGormLike_limitPattern_ReturnsExpectedPattern(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		pattern string
+		limits  Limits
+
+		expected       string
+		expectedReason string
+	}{
+		"no limits":              {pattern: "%a%b%c%", expected: "%a%b%c%"},
+		"exact length":           {pattern: "abc%", limits: Limits{PatternLength: 4}, expected: "abc%"},
+		"too long":               {pattern: "abcd%", limits: Limits{PatternLength: 4}, expectedReason: ReasonPatternTooLong},
+		"length in characters":   {pattern: "ééé%", limits: Limits{PatternLength: 4}, expected: "ééé%"},
+		"truncated length":       {pattern: "abcd%", limits: Limits{PatternLength: 3, Truncate: true}, expected: "ab%"},
+		"truncated on wildcard":  {pattern: "ab%cd", limits: Limits{PatternLength: 3, Truncate: true}, expected: "ab%"},
+		"truncated to wildcard":  {pattern: "abc%", limits: Limits{PatternLength: 1, Truncate: true}, expected: "%"},
+		"too many wildcards":     {pattern: "%a%b%", limits: Limits{Wildcards: 2}, expectedReason: ReasonTooManyWildcards},
+		"truncated wildcards":    {pattern: "a%b%%c%d", limits: Limits{Wildcards: 2, Truncate: true}, expected: "a%b%%"},
+		"truncated length first": {pattern: "a%bcdef", limits: Limits{PatternLength: 4, Wildcards: 1, Truncate: true}, expected: "a%"},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			plugin := newGormLike(WithLimits(testData.limits))
+
+			// Act
+			result, reason := plugin.limitPattern(testData.pattern)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+			assert.Equal(t, testData.expectedReason, reason)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package gormlike
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// mysqlPrefixLength is the length of prefix indexes on MySQL, which fits in the maximum key length of utf8mb4 columns
+const mysqlPrefixLength = 191
+
+// Migrate creates indexes that speed up LIKE queries on the string fields of the models that are tagged with
+// `gormlike:"true"` or enabled in their LikeConfig(). Indexes that already exist are skipped, so it's safe to call
+// this on every start-up.
+//
+//   - PostgreSQL: a trigram GIN index, or a btree index with varchar_pattern_ops for fields tagged with
+//     `gormlike:"true;match:prefix"`. Both are created on the CAST the plugin uses, wrapped in LOWER for
+//     case-insensitive fields, so that its queries can use them. The trigram index requires the pg_trgm extension,
+//     which is created if it doesn't exist.
+//   - MySQL: an index on the first 191 characters, which is only used for patterns that don't start with a wildcard.
+//     The plugin doesn't cast string fields on MySQL, so that its queries can use it.
+//   - SQLite: an FTS5 table named <table>_fts with the trigram tokenizer, kept up to date using triggers. This requires
+//     SQLite to be compiled with FTS5. SQLite can't use it for LIKE queries, so the plugin's queries don't either; it's
+//     meant for your own MATCH queries on the table, e.g. SELECT rowid FROM users_fts WHERE users_fts MATCH 'john'.
+//
+// The statements that were executed are returned. If db is a DryRun session nothing is executed, so the
+// statements that would be executed are returned instead.
+func Migrate(db *gorm.DB, models ...any) ([]string, error) {
+	// Existing indexes are looked up even in a dry run, the session copies the config so db is left alone
+	checker := db.Session(&gorm.Session{})
+	checker.DryRun = false
+
+	var statements []string
+
+	for _, model := range models {
+		statement := &gorm.Statement{DB: db}
+		if err := statement.Parse(model); err != nil {
+			return nil, err
+		}
+
+		fields := likeableStringFields(statement.Schema)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch db.Dialector.Name() {
+		case "postgres":
+			statements = append(statements, postgresIndexes(checker, statement, model, fields)...)
+		case "mysql":
+			statements = append(statements, mysqlIndexes(checker, statement, model, fields)...)
+		case "sqlite":
+			statements = append(statements, sqliteIndexes(checker, statement, fields)...)
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedDialect, db.Dialector.Name())
+		}
+	}
+
+	// The trigram extension only has to be created once, before any of the indexes
+	if db.Dialector.Name() == "postgres" && strings.Contains(strings.Join(statements, "\n"), "gin_trgm_ops") {
+		statements = append([]string{"CREATE EXTENSION IF NOT EXISTS pg_trgm"}, statements...)
+	}
+
+	if db.DryRun {
+		return statements, nil
+	}
+
+	for index, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return statements[:index], err
+		}
+	}
+
+	return statements, nil
+}
+
+// likeableStringFields returns the string fields that are explicitly made likeable by their tag or LikeConfig()
+func likeableStringFields(schemaValue *schema.Schema) []*schema.Field {
+	var result []*schema.Field
+
+	for _, field := range schemaValue.Fields {
+		if field.DBName == "" || field.DataType != schema.String || policyOf(field).value != "true" {
+			continue
+		}
+
+		result = append(result, field)
+	}
+
+	return result
+}
+
+// postgresIndexes returns the statements that create a trigram or pattern index for every field that doesn't have one
+func postgresIndexes(checker *gorm.DB, statement *gorm.Statement, model any, fields []*schema.Field) []string {
+	var result []string
+
+	for _, field := range fields {
+		policy := policyOf(field)
+
+		cast := fmt.Sprintf("CAST(%s as %s)", statement.Quote(field.DBName), textType("postgres"))
+		if policy.caseInsensitive {
+			cast = "LOWER(" + cast + ")"
+		}
+
+		name, definition := "idx_"+statement.Table+"_"+field.DBName+"_trgm", fmt.Sprintf("USING gin ((%s) gin_trgm_ops)", cast)
+		if policy.match == PatternPrefix {
+			name, definition = "idx_"+statement.Table+"_"+field.DBName+"_pattern", fmt.Sprintf("((%s) varchar_pattern_ops)", cast)
+		}
+
+		if checker.Migrator().HasIndex(model, name) {
+			continue
+		}
+
+		result = append(result, fmt.Sprintf("CREATE INDEX %s ON %s %s", statement.Quote(name), statement.Quote(statement.Table), definition))
+	}
+
+	return result
+}
+
+// mysqlIndexes returns the statements that create a prefix index for every field that doesn't have one
+func mysqlIndexes(checker *gorm.DB, statement *gorm.Statement, model any, fields []*schema.Field) []string {
+	var result []string
+
+	for _, field := range fields {
+		name := "idx_" + statement.Table + "_" + field.DBName + "_prefix"
+		if checker.Migrator().HasIndex(model, name) {
+			continue
+		}
+
+		length := mysqlPrefixLength
+		if field.Size > 0 && field.Size < length {
+			length = field.Size
+		}
+
+		result = append(result, fmt.Sprintf("CREATE INDEX %s ON %s (%s(%d))",
+			statement.Quote(name), statement.Quote(statement.Table), statement.Quote(field.DBName), length))
+	}
+
+	return result
+}
+
+// sqliteIndexes returns the statements that create an FTS5 table with the fields, and the triggers that keep it up
+// to date, if the table doesn't exist yet
+func sqliteIndexes(checker *gorm.DB, statement *gorm.Statement, fields []*schema.Field) []string {
+	ftsTable := statement.Table + "_fts"
+	if checker.Migrator().HasTable(ftsTable) {
+		return nil
+	}
+
+	columns := make([]string, 0, len(fields))
+	newValues := make([]string, 0, len(fields))
+	oldValues := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		columns = append(columns, statement.Quote(field.DBName))
+		newValues = append(newValues, "new."+statement.Quote(field.DBName))
+		oldValues = append(oldValues, "old."+statement.Quote(field.DBName))
+	}
+
+	table, fts := statement.Quote(statement.Table), statement.Quote(ftsTable)
+	columnList := strings.Join(columns, ", ")
+
+	insert := fmt.Sprintf("INSERT INTO %s(rowid, %s) VALUES (new.rowid, %s);", fts, columnList, strings.Join(newValues, ", "))
+	remove := fmt.Sprintf("INSERT INTO %s(%s, rowid, %s) VALUES ('delete', old.rowid, %s);", fts, fts, columnList, strings.Join(oldValues, ", "))
+
+	return []string{
+		fmt.Sprintf("CREATE VIRTUAL TABLE %s USING fts5(%s, content='%s', tokenize='trigram')", fts, columnList, statement.Table),
+		fmt.Sprintf("CREATE TRIGGER %s AFTER INSERT ON %s BEGIN %s END", statement.Quote(ftsTable+"_insert"), table, insert),
+		fmt.Sprintf("CREATE TRIGGER %s AFTER DELETE ON %s BEGIN %s END", statement.Quote(ftsTable+"_delete"), table, remove),
+		fmt.Sprintf("CREATE TRIGGER %s AFTER UPDATE ON %s BEGIN %s %s END", statement.Quote(ftsTable+"_update"), table, remove, insert),
+		fmt.Sprintf("INSERT INTO %s(%s) VALUES ('rebuild')", fts, fts),
+	}
+}
@@ -0,0 +1,68 @@
+//go:build sqlite_fts5
+
+package gormlike
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate_KeepsFTSTableUpToDate(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t)
+	require.NoError(t, db.AutoMigrate(&ObjectK{}))
+
+	// matches returns the ids of the rows whose likeable fields contain the text
+	matches := func(text string) []int {
+		var result []int
+		require.NoError(t, db.Raw("SELECT rowid FROM object_ks_fts WHERE object_ks_fts MATCH ? ORDER BY rowid", `"`+text+`"`).Scan(&result).Error)
+
+		return result
+	}
+
+	// Act
+	first, err := Migrate(db, &ObjectK{})
+	require.NoError(t, err)
+
+	second, err := Migrate(db, &ObjectK{})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Len(t, first, 5)
+	assert.Empty(t, second)
+
+	require.NoError(t, db.Create(&[]ObjectK{{ID: 1, Name: "jessica", Code: "NL-1", Other: "secret"}, {ID: 2, Name: "john"}}).Error)
+	assert.Equal(t, []int{1}, matches("ess"))
+	assert.Equal(t, []int{1}, matches("NL-"))
+	assert.Empty(t, matches("secret"))
+
+	require.NoError(t, db.Model(&ObjectK{ID: 1}).Update("name", "amy").Error)
+	assert.Empty(t, matches("ess"))
+	assert.Equal(t, []int{1}, matches("amy"))
+
+	require.NoError(t, db.Delete(&ObjectK{ID: 1}).Error)
+	assert.Empty(t, matches("amy"))
+	assert.Equal(t, []int{2}, matches("joh"))
+}
+
+func TestMigrate_IndexesExistingRows(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t)
+	require.NoError(t, db.AutoMigrate(&ObjectK{}))
+	require.NoError(t, db.Create(&ObjectK{ID: 1, Name: "jessica"}).Error)
+
+	// Act
+	_, err := Migrate(db, &ObjectK{})
+
+	// Assert
+	require.NoError(t, err)
+
+	var actual []int
+	require.NoError(t, db.Raw("SELECT rowid FROM object_ks_fts WHERE object_ks_fts MATCH ?", `"ssi"`).Scan(&actual).Error)
+	assert.Equal(t, []int{1}, actual)
+}
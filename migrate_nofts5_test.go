@@ -0,0 +1,26 @@
+//go:build !sqlite_fts5
+
+package gormlike
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate_ReturnsErrorIfStatementFails(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t)
+	require.NoError(t, db.AutoMigrate(&ObjectK{}))
+
+	// Act
+	result, err := Migrate(db, &ObjectK{})
+
+	// Assert
+	// Without the sqlite_fts5 tag the SQLite driver is compiled without FTS5, so the first statement fails
+	require.ErrorContains(t, err, "fts5")
+	assert.Empty(t, result)
+}
@@ -0,0 +1,178 @@
+package gormlike
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type ObjectK struct {
+	ID     int
+	Name   string `gormlike:"true"`
+	Code   string `gorm:"size:20" gormlike:"true;match:prefix"`
+	Other  string
+	Hidden string `gormlike:"false"`
+	Age    int    `gormlike:"true"`
+}
+
+type ObjectL struct {
+	ID   int
+	Name string
+}
+
+type ObjectLConfigured struct {
+	ID   int
+	Name string
+	Code string
+}
+
+func (ObjectLConfigured) LikeConfig() ModelConfig {
+	return ModelConfig{Columns: map[string]ColumnConfig{"name": {CaseInsensitive: true}, "code": {Disabled: true}}}
+}
+
+func TestMigrate_ReturnsExpectedStatements(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		dialect  string
+		existing []string
+		models   []any
+		expected []string
+	}{
+		"postgres": {
+			dialect: "postgres",
+			models:  []any{&ObjectK{}},
+			expected: []string{
+				"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+				"CREATE INDEX `idx_object_ks_name_trgm` ON `object_ks` USING gin ((CAST(`name` as varchar)) gin_trgm_ops)",
+				"CREATE INDEX `idx_object_ks_code_pattern` ON `object_ks` ((CAST(`code` as varchar)) varchar_pattern_ops)",
+			},
+		},
+		"postgres with existing trigram index": {
+			dialect:  "postgres",
+			existing: []string{"CREATE INDEX idx_object_ks_name_trgm ON object_ks (name)"},
+			models:   []any{&ObjectK{}},
+			expected: []string{
+				"CREATE INDEX `idx_object_ks_code_pattern` ON `object_ks` ((CAST(`code` as varchar)) varchar_pattern_ops)",
+			},
+		},
+		"postgres with all indexes": {
+			dialect: "postgres",
+			existing: []string{
+				"CREATE INDEX idx_object_ks_name_trgm ON object_ks (name)",
+				"CREATE INDEX idx_object_ks_code_pattern ON object_ks (code)",
+			},
+			models:   []any{&ObjectK{}},
+			expected: nil,
+		},
+		"mysql": {
+			dialect: "mysql",
+			models:  []any{&ObjectK{}},
+			expected: []string{
+				"CREATE INDEX `idx_object_ks_name_prefix` ON `object_ks` (`name`(191))",
+				"CREATE INDEX `idx_object_ks_code_prefix` ON `object_ks` (`code`(20))",
+			},
+		},
+		"mysql with existing index": {
+			dialect:  "mysql",
+			existing: []string{"CREATE INDEX idx_object_ks_name_prefix ON object_ks (name)"},
+			models:   []any{&ObjectK{}},
+			expected: []string{
+				"CREATE INDEX `idx_object_ks_code_prefix` ON `object_ks` (`code`(20))",
+			},
+		},
+		"sqlite": {
+			dialect: "sqlite",
+			models:  []any{&ObjectK{}},
+			expected: []string{
+				"CREATE VIRTUAL TABLE `object_ks_fts` USING fts5(`name`, `code`, content='object_ks', tokenize='trigram')",
+				"CREATE TRIGGER `object_ks_fts_insert` AFTER INSERT ON `object_ks` BEGIN " +
+					"INSERT INTO `object_ks_fts`(rowid, `name`, `code`) VALUES (new.rowid, new.`name`, new.`code`); END",
+				"CREATE TRIGGER `object_ks_fts_delete` AFTER DELETE ON `object_ks` BEGIN " +
+					"INSERT INTO `object_ks_fts`(`object_ks_fts`, rowid, `name`, `code`) VALUES ('delete', old.rowid, old.`name`, old.`code`); END",
+				"CREATE TRIGGER `object_ks_fts_update` AFTER UPDATE ON `object_ks` BEGIN " +
+					"INSERT INTO `object_ks_fts`(`object_ks_fts`, rowid, `name`, `code`) VALUES ('delete', old.rowid, old.`name`, old.`code`); " +
+					"INSERT INTO `object_ks_fts`(rowid, `name`, `code`) VALUES (new.rowid, new.`name`, new.`code`); END",
+				"INSERT INTO `object_ks_fts`(`object_ks_fts`) VALUES ('rebuild')",
+			},
+		},
+		"sqlite with existing table": {
+			dialect:  "sqlite",
+			existing: []string{"CREATE TABLE object_ks_fts (name text)"},
+			models:   []any{&ObjectK{}},
+			expected: nil,
+		},
+		"postgres with like config": {
+			dialect: "postgres",
+			models:  []any{&ObjectLConfigured{}},
+			expected: []string{
+				"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+				"CREATE INDEX `idx_object_l_configureds_name_trgm` ON `object_l_configureds` USING gin ((LOWER(CAST(`name` as varchar))) gin_trgm_ops)",
+			},
+		},
+		"mysql with like config": {
+			dialect: "mysql",
+			models:  []any{&ObjectLConfigured{}},
+			expected: []string{
+				"CREATE INDEX `idx_object_l_configureds_name_prefix` ON `object_l_configureds` (`name`(191))",
+			},
+		},
+		"no tagged fields": {
+			dialect:  "postgres",
+			models:   []any{&ObjectL{}},
+			expected: nil,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			require.NoError(t, db.AutoMigrate(&ObjectK{}, &ObjectL{}, &ObjectLConfigured{}))
+
+			for _, statement := range testData.existing {
+				require.NoError(t, db.Exec(statement).Error)
+			}
+
+			db.Dialector = namedDialector{Dialector: db.Dialector, name: testData.dialect}
+
+			// Act
+			result, err := Migrate(db.Session(&gorm.Session{DryRun: true}), testData.models...)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestMigrate_ReturnsErrorOnUnsupportedDialect(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t)
+	db.Dialector = namedDialector{Dialector: db.Dialector, name: "sqlserver"}
+
+	// Act
+	result, err := Migrate(db, &ObjectK{})
+
+	// Assert
+	require.ErrorIs(t, err, ErrUnsupportedDialect)
+	assert.Nil(t, result)
+}
+
+func TestMigrate_ReturnsErrorOnInvalidModel(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t)
+
+	// Act
+	result, err := Migrate(db, 1)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, result)
+}
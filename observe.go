@@ -0,0 +1,149 @@
+package gormlike
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PatternKind describes where the wildcards are in a pattern, which says something about how well it can use an index
+type PatternKind string
+
+const (
+	// PatternPrefix is a pattern like `jo%`
+	PatternPrefix PatternKind = "prefix"
+
+	// PatternSuffix is a pattern like `%jo`
+	PatternSuffix PatternKind = "suffix"
+
+	// PatternContains is a pattern like `%jo%`
+	PatternContains PatternKind = "contains"
+
+	// PatternComplex is a pattern with wildcards in the middle, like `j%o`
+	PatternComplex PatternKind = "complex"
+)
+
+// patternKind returns the kind of the pattern, based on the position of its wildcards
+func patternKind(pattern string) PatternKind {
+	if strings.Contains(strings.Trim(pattern, "%"), "%") {
+		return PatternComplex
+	}
+
+	leading, trailing := strings.HasPrefix(pattern, "%"), strings.HasSuffix(pattern, "%")
+
+	switch {
+	case leading && trailing:
+		return PatternContains
+	case leading:
+		return PatternSuffix
+	case trailing:
+		return PatternPrefix
+	default:
+		return PatternComplex
+	}
+}
+
+// Observation describes a query that the plugin turned into a LIKE query
+type Observation struct {
+	// Table is the table of the query
+	Table string
+
+	// Conditions are the conditions that were converted, one for every pattern
+	Conditions []Decision
+
+	// Elapsed is the time it took to execute the query
+	Elapsed time.Duration
+
+	// Rows is the number of rows the query returned, this is unknown for Row(), Rows() and Scan()
+	Rows int64
+
+	// RowsKnown is whether Rows is known, which it isn't for Row(), Rows() and Scan()
+	RowsKnown bool
+
+	// Error is the error of the query, if any
+	Error error
+}
+
+// Observer is notified of every query that the plugin turned into a LIKE query, after it was executed. It can be
+// used to gather metrics on which tables and columns are searched and how slow that is.
+type Observer interface {
+	Observe(ctx context.Context, observation Observation)
+}
+
+// Compile-time interface check
+var _ Observer = new(MemoryObserver)
+
+// MemoryObserver is an Observer that keeps all observations in memory, useful in tests
+type MemoryObserver struct {
+	mutex        sync.Mutex
+	observations []Observation
+}
+
+// Observe adds the observation
+func (m *MemoryObserver) Observe(_ context.Context, observation Observation) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.observations = append(m.observations, observation)
+}
+
+// Observations returns the observations so far
+func (m *MemoryObserver) Observations() []Observation {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return slices.Clone(m.observations)
+}
+
+// startObservation records the start of the query if any of its conditions were converted
+func (d *gormLike) startObservation(db *gorm.DB) {
+	if d.observer == nil || len(d.convertedDecisions(db)) == 0 {
+		return
+	}
+
+	db.InstanceSet(d.startKey, time.Now())
+}
+
+// observeCallback notifies the observer of a query that was converted, after it was executed
+func (d *gormLike) observeCallback(db *gorm.DB) {
+	start, ok := db.InstanceGet(d.startKey)
+	if !ok {
+		return
+	}
+
+	startTime, _ := start.(time.Time)
+	if startTime.IsZero() {
+		return
+	}
+
+	// The statement might be executed again, which should not count as a converted query unless it is converted again
+	db.InstanceSet(d.startKey, time.Time{})
+
+	// The configuration of a Controller might have been updated while the query was running
+	if d.observer == nil {
+		return
+	}
+
+	// Row() and Rows() set the rows setting and leave the rows to the caller
+	_, rowsUnknown := db.Get("rows")
+
+	d.observer.Observe(db.Statement.Context, Observation{
+		Table:      db.Statement.Table,
+		Conditions: d.convertedDecisions(db),
+		Elapsed:    time.Since(startTime),
+		Rows:       db.RowsAffected,
+		RowsKnown:  !rowsUnknown,
+		Error:      db.Error,
+	})
+}
+
+// convertedDecisions returns the decisions of the statement that resulted in a LIKE query
+func (d *gormLike) convertedDecisions(db *gorm.DB) []Decision {
+	return slices.DeleteFunc(Explanation(db, d.name), func(decision Decision) bool {
+		return !decision.Converted
+	})
+}
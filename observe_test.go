@@ -0,0 +1,292 @@
+package gormlike
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestPatternKind_ReturnsExpectedKind(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		pattern  string
+		expected PatternKind
+	}{
+		"prefix":                {pattern: "jo%", expected: PatternPrefix},
+		"suffix":                {pattern: "%jo", expected: PatternSuffix},
+		"contains":              {pattern: "%jo%", expected: PatternContains},
+		"only a wildcard":       {pattern: "%", expected: PatternContains},
+		"wildcard in middle":    {pattern: "j%o", expected: PatternComplex},
+		"prefix with a middle":  {pattern: "j%o%", expected: PatternComplex},
+		"multiple wildcards":    {pattern: "%%jo%%", expected: PatternContains},
+		"no wildcards (tokens)": {pattern: "jo", expected: PatternComplex},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := patternKind(testData.pattern)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestGormLike_Initialize_NotifiesObserver(t *testing.T) {
+	t.Parallel()
+
+	type ObjectI struct {
+		ID   int
+		Name string
+		City string
+	}
+
+	existing := []ObjectI{{ID: 1, Name: "jessica", City: "amsterdam"}, {ID: 2, Name: "john", City: "utrecht"}}
+
+	tests := map[string]struct {
+		query    func(*gorm.DB) *gorm.DB
+		options  []Option
+		expected []Observation
+	}{
+		"no wildcards": {
+			query:    func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "jessica"}) },
+			expected: nil,
+		},
+		"prefix": {
+			query: func(db *gorm.DB) *gorm.DB { return db.Where(map[string]any{"name": "j%"}) },
+			expected: []Observation{
+				{
+					Table:      "object_is",
+					Conditions: []Decision{{Table: "object_is", Column: "name", Converted: true, Pattern: PatternPrefix, Value: "j%", SQL: "CAST(`object_is`.`name` as varchar) LIKE ?"}},
+					Rows:       2,
+					RowsKnown:  true,
+				},
+			},
+		},
+		"multiple columns": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where(map[string]any{"name": "%a"}).Where(map[string]any{"city": []string{"%dam", "%am%"}})
+			},
+			expected: []Observation{
+				{
+					Table: "object_is",
+					Conditions: []Decision{
+						{Table: "object_is", Column: "name", Converted: true, Pattern: PatternSuffix, Value: "%a", SQL: "CAST(`object_is`.`name` as varchar) LIKE ?"},
+						{Table: "object_is", Column: "city", Converted: true, Pattern: PatternSuffix, Value: "%dam", SQL: "CAST(`object_is`.`city` as varchar) LIKE ?"},
+						{Table: "object_is", Column: "city", Converted: true, Pattern: PatternContains, Value: "%am%", SQL: "CAST(`object_is`.`city` as varchar) LIKE ?"},
+					},
+					Rows:      1,
+					RowsKnown: true,
+				},
+			},
+		},
+		"tokenised": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Set("gormlike:tokenise", true).Where(map[string]any{"name": "jess ca"})
+			},
+			options: []Option{Tokenised()},
+			expected: []Observation{
+				{
+					Table: "object_is",
+					Conditions: []Decision{
+						{Table: "object_is", Column: "name", Converted: true, Pattern: PatternContains, Value: "%jess%", SQL: "CAST(`object_is`.`name` as varchar) LIKE ?"},
+						{Table: "object_is", Column: "name", Converted: true, Pattern: PatternContains, Value: "%ca%", SQL: "CAST(`object_is`.`name` as varchar) LIKE ?"},
+					},
+					Rows:      1,
+					RowsKnown: true,
+				},
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectI{})
+			require.NoError(t, db.Create(existing).Error)
+
+			observer := &MemoryObserver{}
+			require.NoError(t, db.Use(New(append(testData.options, WithObserver(observer))...)))
+
+			var actual []ObjectI
+
+			// Act
+			err := testData.query(db).Find(&actual).Error
+
+			// Assert
+			require.NoError(t, err)
+
+			observations := observer.Observations()
+			require.Len(t, observations, len(testData.expected))
+
+			for index, observation := range observations {
+				assert.Positive(t, observation.Elapsed)
+
+				observation.Elapsed = 0
+				assert.Equal(t, testData.expected[index], observation)
+			}
+		})
+	}
+}
+
+func TestGormLike_Initialize_NotifiesObserverOncePerQuery(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	type ObjectI struct {
+		Name string
+	}
+
+	db := gormtestutil.NewMemoryDatabase(t)
+	_ = db.AutoMigrate(&ObjectI{})
+
+	observer := &MemoryObserver{}
+	require.NoError(t, db.Use(New(WithObserver(observer))))
+
+	session := db.Where(map[string]any{"name": "j%"}).Session(&gorm.Session{})
+
+	var actual []ObjectI
+
+	// Act
+	require.NoError(t, session.Find(&actual).Error)
+	require.NoError(t, session.Where(map[string]any{"name": "john"}).Find(&actual).Error)
+	require.NoError(t, db.Where(map[string]any{"name": "john"}).Find(&actual).Error)
+
+	var count int64
+
+	require.NoError(t, session.Model(&ObjectI{}).Select("COUNT(*)").Row().Scan(&count))
+
+	// Assert
+	assert.Len(t, observer.Observations(), 3)
+}
+
+func TestGormLike_Initialize_ObservesUnknownRowsOfRow(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	type ObjectI struct {
+		Name string
+	}
+
+	db := gormtestutil.NewMemoryDatabase(t)
+	_ = db.AutoMigrate(&ObjectI{})
+
+	observer := &MemoryObserver{}
+	require.NoError(t, db.Use(New(WithObserver(observer))))
+
+	var count int64
+
+	// Act
+	err := db.Model(&ObjectI{}).Where(map[string]any{"name": "j%"}).Select("COUNT(*)").Row().Scan(&count)
+
+	// Assert
+	require.NoError(t, err)
+
+	observations := observer.Observations()
+	require.Len(t, observations, 1)
+	assert.False(t, observations[0].RowsKnown)
+}
+
+func TestGormLike_Initialize_NotifiesObserversOfNamedInstances(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	type ObjectI struct {
+		ID   int
+		Name string `gormlike:"true"`
+	}
+
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectI{})
+
+	observerA := &MemoryObserver{}
+	observerB := &MemoryObserver{}
+	require.NoError(t, db.Use(New(WithName("a"), TaggedOnly(), WithObserver(observerA))))
+	require.NoError(t, db.Use(New(WithName("b"), SettingOnly(), WithObserver(observerB))))
+
+	var actual []ObjectI
+
+	// Act
+	tx := db.Set("a:explain", true).Set("b:explain", true).Where(map[string]any{"name": "j%"}).Find(&actual)
+
+	// Assert
+	require.NoError(t, tx.Error)
+
+	require.Len(t, observerA.Observations(), 1)
+	assert.Equal(t, []Decision{{
+		Table:     "object_is",
+		Column:    "name",
+		Converted: true,
+		Pattern:   PatternPrefix,
+		Value:     "j%",
+		SQL:       "CAST(`object_is`.`name` as varchar) LIKE ?",
+	}}, observerA.Observations()[0].Conditions)
+	assert.Empty(t, observerB.Observations())
+
+	assert.Len(t, Explanation(tx, "a"), 1)
+	assert.Equal(t, []Decision{{Table: "object_is", Reason: ReasonSettingDisabled}}, Explanation(tx, "b"))
+	assert.Len(t, Explanation(tx, "a", "b"), 2)
+	assert.Empty(t, Explanation(tx))
+}
+
+func TestGormLike_Initialize_OrdersObserveCallbacks(t *testing.T) {
+	t.Parallel()
+
+	type ObjectI struct {
+		Name string
+	}
+
+	tests := map[string]struct {
+		options []Option
+
+		expected []int
+	}{
+		"before other callback": {
+			options:  []Option{ObserveBefore("audit:after")},
+			expected: []int{1, 2},
+		},
+		"after other callback": {
+			options:  []Option{ObserveAfter("audit:after")},
+			expected: []int{0, 1},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectI{})
+
+			observer := &MemoryObserver{}
+
+			// The number of observations every audit callback has seen
+			var actual []int
+
+			audit := func(*gorm.DB) { actual = append(actual, len(observer.Observations())) }
+			require.NoError(t, db.Callback().Query().After("gorm:query").Register("audit:after", audit))
+			require.NoError(t, db.Callback().Row().After("gorm:row").Register("audit:after", audit))
+
+			// Act
+			err := db.Use(New(append(testData.options, WithObserver(observer))...))
+
+			// Assert
+			require.NoError(t, err)
+
+			var objects []ObjectI
+
+			require.NoError(t, db.Where(map[string]any{"name": "j%"}).Find(&objects).Error)
+
+			var count int64
+
+			require.NoError(t, db.Model(&ObjectI{}).Where(map[string]any{"name": "j%"}).Select("COUNT(*)").Row().Scan(&count))
+
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package gormlike
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// operatorPrefixes are the operators that WithOperators() recognises at the start of a value, longer ones first
+var operatorPrefixes = []string{">=", "<=", ">", "<", "!"}
+
+// rangeSeparator separates the bounds of a range, e.g. 18..65
+const rangeSeparator = ".."
+
+// timeLayouts are the layouts that operands of time fields may use
+var timeLayouts = []string{time.RFC3339Nano, time.DateTime, time.DateOnly}
+
+// parseOperator splits the value into its operator and operand, returns false if it has no operator
+func parseOperator(value string) (string, string, bool) {
+	for _, operator := range operatorPrefixes {
+		if operand, ok := strings.CutPrefix(value, operator); ok {
+			return operator, strings.TrimSpace(operand), true
+		}
+	}
+
+	if strings.Contains(value, rangeSeparator) {
+		return rangeSeparator, value, true
+	}
+
+	return "", "", false
+}
+
+// coerce turns the operand into a value of the type of the field, returns false if it doesn't fit
+func coerce(field *schema.Field, operand string) (any, bool) {
+	fieldType := field.IndirectFieldType
+
+	if fieldType == reflect.TypeOf(time.Time{}) {
+		for _, layout := range timeLayouts {
+			if value, err := time.Parse(layout, operand); err == nil {
+				return value, true
+			}
+		}
+
+		return nil, false
+	}
+
+	var value any
+
+	var err error
+
+	//nolint:exhaustive // Other kinds are not supported
+	switch fieldType.Kind() {
+	case reflect.String:
+		return operand, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err = strconv.ParseInt(operand, 10, fieldType.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err = strconv.ParseUint(operand, 10, fieldType.Bits())
+	case reflect.Float32, reflect.Float64:
+		value, err = strconv.ParseFloat(operand, fieldType.Bits())
+	case reflect.Bool:
+		value, err = strconv.ParseBool(operand)
+	default:
+		return nil, false
+	}
+
+	return value, err == nil
+}
+
+// replaceOperator turns a value with an operator of WithOperators() into the matching condition, e.g. >=18 into
+// age >= 18. Returns false if the value has no operator, the expression is nil if the condition should be left alone.
+func (d *gormLike) replaceOperator(db *gorm.DB, src source, cond clause.Eq) (clause.Expression, bool) {
+	value, valueOk := cond.Value.(string)
+	if !d.operators || !valueOk {
+		return nil, false
+	}
+
+	operator, operand, ok := parseOperator(value)
+	if !ok {
+		return nil, false
+	}
+
+	column, columnOk := cond.Column.(clause.Column)
+	if !columnOk {
+		return nil, false
+	}
+
+	// Negated wildcards are NOT LIKE queries, which are subject to the same rules as LIKE queries
+	if operator == "!" && d.hasWildcard(operand) {
+		return d.notLikeExpression(db, src, column, operand), true
+	}
+
+	// Comparisons reveal as much about a field as LIKE queries, so they follow the same rules
+	target, ok := d.likeableTarget(db, src, column)
+	if !ok {
+		return nil, true
+	}
+
+	if target.field == nil {
+		d.skip(db, src, column, ReasonUnknownField)
+
+		return nil, true
+	}
+
+	// Comparisons need the column itself, not a path in a JSON column, an association or the elements of an array
+	field := target.field
+	if field.DBName != column.Name || isNativeArrayField(field) || isJSONArrayField(field) {
+		d.skip(db, src, column, ReasonUnsupportedColumn)
+
+		return nil, true
+	}
+
+	if operator == rangeSeparator {
+		return d.rangeExpression(db, src, column, field, operand)
+	}
+
+	coerced, ok := coerce(field, operand)
+	if !ok {
+		d.skip(db, src, column, ReasonInvalidOperand)
+
+		return nil, true
+	}
+
+	switch operator {
+	case ">":
+		return clause.Gt{Column: column, Value: coerced}, true
+	case ">=":
+		return clause.Gte{Column: column, Value: coerced}, true
+	case "<":
+		return clause.Lt{Column: column, Value: coerced}, true
+	case "<=":
+		return clause.Lte{Column: column, Value: coerced}, true
+	default:
+		return clause.Neq{Column: column, Value: coerced}, true
+	}
+}
+
+// rangeExpression turns a range like 18..65 into a BETWEEN condition, either bound may be left out, e.g. 18.. is
+// turned into >= 18. Strings are left alone, as .. is more likely to be part of the text.
+func (d *gormLike) rangeExpression(db *gorm.DB, src source, column clause.Column, field *schema.Field, operand string) (clause.Expression, bool) {
+	if field.IndirectFieldType.Kind() == reflect.String {
+		return nil, false
+	}
+
+	lower, upper, _ := strings.Cut(operand, rangeSeparator)
+	lower, upper = strings.TrimSpace(lower), strings.TrimSpace(upper)
+
+	lowerValue, lowerOk := coerce(field, lower)
+	upperValue, upperOk := coerce(field, upper)
+
+	switch {
+	case lowerOk && upperOk:
+		return clause.Expr{SQL: "? BETWEEN ? AND ?", Vars: []any{column, lowerValue, upperValue}}, true
+	case lowerOk && upper == "":
+		return clause.Gte{Column: column, Value: lowerValue}, true
+	case upperOk && lower == "":
+		return clause.Lte{Column: column, Value: upperValue}, true
+	default:
+		d.skip(db, src, column, ReasonInvalidOperand)
+
+		return nil, true
+	}
+}
+
+// notLikeExpression turns the pattern into a NOT LIKE condition, returns nil if the column is not likeable
+func (d *gormLike) notLikeExpression(db *gorm.DB, src source, column clause.Column, pattern string) clause.Expression {
+	target, ok := d.likeableTarget(db, src, column)
+	if !ok {
+		return nil
+	}
+
+	policy := target.policy()
+
+	pattern, reason := d.limitPattern(d.replaceWildcards(pattern))
+	if reason != "" {
+		d.skip(db, src, column, reason)
+
+		return nil
+	}
+
+	if !policy.allows(pattern) {
+		d.skip(db, src, column, ReasonPatternNotAllowed)
+
+		return nil
+	}
+
+	condition := target.condition(policy.comparison())
+	d.converted(db, src, column, condition, pattern)
+
+	return clause.Not(clause.Expr{SQL: condition, Vars: []any{pattern}})
+}
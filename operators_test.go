@@ -0,0 +1,301 @@
+package gormlike
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+type ObjectW struct {
+	ID     int
+	Name   string
+	Age    uint
+	Score  float64
+	Active bool
+	Born   time.Time
+	Secret string   `gormlike:"false"`
+	Tags   []string `gorm:"serializer:json"`
+}
+
+func TestGormLike_Initialize_ConvertsOperators(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter           map[string]any
+		options          []Option
+		withoutOperators bool
+
+		expected     string
+		expectedVars []any
+		expectedErr  error
+	}{
+		"greater than": {
+			filter:       map[string]any{"age": ">18"},
+			expected:     "SELECT * FROM `object_ws` WHERE `object_ws`.`age` > ?",
+			expectedVars: []any{uint64(18)},
+		},
+		"greater than or equal with space": {
+			filter:       map[string]any{"score": ">= 1.5"},
+			expected:     "SELECT * FROM `object_ws` WHERE `object_ws`.`score` >= ?",
+			expectedVars: []any{1.5},
+		},
+		"less than": {
+			filter:       map[string]any{"born": "<2000-01-01"},
+			expected:     "SELECT * FROM `object_ws` WHERE `object_ws`.`born` < ?",
+			expectedVars: []any{time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		"less than or equal on a string": {
+			filter:       map[string]any{"name": "<=m"},
+			expected:     "SELECT * FROM `object_ws` WHERE `object_ws`.`name` <= ?",
+			expectedVars: []any{"m"},
+		},
+		"not equal": {
+			filter:       map[string]any{"active": "!true"},
+			expected:     "SELECT * FROM `object_ws` WHERE `object_ws`.`active` <> ?",
+			expectedVars: []any{true},
+		},
+		"not like": {
+			filter:       map[string]any{"name": "!jo%"},
+			expected:     "SELECT * FROM `object_ws` WHERE NOT CAST(`object_ws`.`name` as varchar) LIKE ?",
+			expectedVars: []any{"jo%"},
+		},
+		"not like on array": {
+			filter:       map[string]any{"tags": "!prod-%"},
+			expected:     "SELECT * FROM `object_ws` WHERE NOT EXISTS (SELECT 1 FROM json_each(`object_ws`.`tags`) WHERE CAST(value as varchar) LIKE ?)",
+			expectedVars: []any{"prod-%"},
+		},
+		"not like with replacement character": {
+			filter:       map[string]any{"name": "!jo*"},
+			options:      []Option{WithCharacter("*")},
+			expected:     "SELECT * FROM `object_ws` WHERE NOT CAST(`object_ws`.`name` as varchar) LIKE ?",
+			expectedVars: []any{"jo%"},
+		},
+		"not equal on a field that isn't likeable": {
+			filter:       map[string]any{"secret": "!abc"},
+			expected:     "SELECT * FROM `object_ws` WHERE `object_ws`.`secret` = ?",
+			expectedVars: []any{"!abc"},
+		},
+		"comparison on a field that isn't likeable": {
+			filter:       map[string]any{"secret": ">m"},
+			expected:     "SELECT * FROM `object_ws` WHERE `object_ws`.`secret` = ?",
+			expectedVars: []any{">m"},
+		},
+		"comparison on an untagged field with tagged only": {
+			filter:       map[string]any{"age": ">18"},
+			options:      []Option{TaggedOnly()},
+			expected:     "SELECT * FROM `object_ws` WHERE `object_ws`.`age` = ?",
+			expectedVars: []any{">18"},
+		},
+		"range": {
+			filter:       map[string]any{"age": "18..65"},
+			expected:     "SELECT * FROM `object_ws` WHERE `object_ws`.`age` BETWEEN ? AND ?",
+			expectedVars: []any{uint64(18), uint64(65)},
+		},
+		"range without upper bound": {
+			filter:       map[string]any{"score": "1.5.."},
+			expected:     "SELECT * FROM `object_ws` WHERE `object_ws`.`score` >= ?",
+			expectedVars: []any{1.5},
+		},
+		"range without lower bound": {
+			filter:       map[string]any{"age": "..65"},
+			expected:     "SELECT * FROM `object_ws` WHERE `object_ws`.`age` <= ?",
+			expectedVars: []any{uint64(65)},
+		},
+		"range on a string": {
+			filter:       map[string]any{"name": "wait..%"},
+			expected:     "SELECT * FROM `object_ws` WHERE CAST(`object_ws`.`name` as varchar) LIKE ?",
+			expectedVars: []any{"wait..%"},
+		},
+		"combined with like": {
+			filter:       map[string]any{"age": ">=18", "name": "jo%"},
+			expected:     "SELECT * FROM `object_ws` WHERE `object_ws`.`age` >= ? AND CAST(`object_ws`.`name` as varchar) LIKE ?",
+			expectedVars: []any{uint64(18), "jo%"},
+		},
+		"without option": {
+			filter:           map[string]any{"name": ">=18"},
+			withoutOperators: true,
+			expected:         "SELECT * FROM `object_ws` WHERE `object_ws`.`name` = ?",
+			expectedVars:     []any{">=18"},
+		},
+		"invalid operand": {
+			filter:       map[string]any{"age": ">=abc"},
+			expected:     "SELECT * FROM `object_ws` WHERE `object_ws`.`age` = ?",
+			expectedVars: []any{">=abc"},
+		},
+		"negative number on unsigned field": {
+			filter:       map[string]any{"age": "<-1"},
+			expected:     "SELECT * FROM `object_ws` WHERE `object_ws`.`age` = ?",
+			expectedVars: []any{"<-1"},
+		},
+		"invalid operand in strict mode": {
+			filter:      map[string]any{"age": "18..abc"},
+			options:     []Option{Strict()},
+			expectedErr: ErrInvalidOperand,
+		},
+		"unknown field in strict mode": {
+			filter:      map[string]any{"unknown": ">1"},
+			options:     []Option{Strict()},
+			expectedErr: ErrUnknownField,
+		},
+		"comparison on array in strict mode": {
+			filter:      map[string]any{"tags": ">a"},
+			options:     []Option{Strict()},
+			expectedErr: ErrUnsupportedColumn,
+		},
+		"comparison on a field that isn't likeable in strict mode": {
+			filter:      map[string]any{"secret": "<m"},
+			options:     []Option{Strict()},
+			expectedErr: ErrFieldNotLikeable,
+		},
+		"not like on a field that isn't likeable in strict mode": {
+			filter:      map[string]any{"secret": "!a%"},
+			options:     []Option{Strict()},
+			expectedErr: ErrFieldNotLikeable,
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+			options := testData.options
+			if !testData.withoutOperators {
+				options = append(options, WithOperators())
+			}
+
+			// Act
+			err := db.Use(New(options...))
+
+			// Assert
+			require.NoError(t, err)
+
+			var actual []ObjectW
+			query := db.Session(&gorm.Session{DryRun: true}).Where(testData.filter).Find(&actual)
+
+			if testData.expectedErr != nil {
+				require.ErrorIs(t, query.Error, testData.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, query.Error)
+			assert.Equal(t, testData.expected, query.Statement.SQL.String())
+			assert.Equal(t, testData.expectedVars, query.Statement.Vars)
+		})
+	}
+}
+
+func TestGormLike_Initialize_OperatorsReturnExpectedRows(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+	_ = db.AutoMigrate(&ObjectW{})
+
+	existing := []ObjectW{
+		{ID: 1, Name: "john", Age: 17, Active: true},
+		{ID: 2, Name: "jessica", Age: 18, Active: true},
+		{ID: 3, Name: "amy", Age: 40},
+		{ID: 4, Name: "joanne", Age: 70, Active: true},
+	}
+	require.NoError(t, db.Create(&existing).Error)
+
+	// Act
+	err := db.Use(New(WithOperators()))
+
+	// Assert
+	require.NoError(t, err)
+
+	var actual []ObjectW
+	require.NoError(t, db.Where(map[string]any{"age": "18..65", "name": "!jo%"}).Order("id").Find(&actual).Error)
+
+	var ids []int
+	for _, object := range actual {
+		ids = append(ids, object.ID)
+	}
+
+	assert.Equal(t, []int{2, 3}, ids)
+}
+
+func TestParseOperator_ReturnsExpectedOperator(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value string
+
+		expectedOperator string
+		expectedOperand  string
+		expectedOk       bool
+	}{
+		"none":                   {value: "abc"},
+		"greater than":           {value: ">1", expectedOperator: ">", expectedOperand: "1", expectedOk: true},
+		"greater than or equal":  {value: ">= 1", expectedOperator: ">=", expectedOperand: "1", expectedOk: true},
+		"less than":              {value: "<1", expectedOperator: "<", expectedOperand: "1", expectedOk: true},
+		"less than or equal":     {value: "<=1", expectedOperator: "<=", expectedOperand: "1", expectedOk: true},
+		"not":                    {value: "!a%", expectedOperator: "!", expectedOperand: "a%", expectedOk: true},
+		"range":                  {value: "1..2", expectedOperator: "..", expectedOperand: "1..2", expectedOk: true},
+		"operator in the middle": {value: "a>b"},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			operator, operand, ok := parseOperator(testData.value)
+
+			// Assert
+			assert.Equal(t, testData.expectedOperator, operator)
+			assert.Equal(t, testData.expectedOperand, operand)
+			assert.Equal(t, testData.expectedOk, ok)
+		})
+	}
+}
+
+func TestCoerce_ReturnsValueOfFieldType(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := schema.Parse(&ObjectW{}, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		field   string
+		operand string
+
+		expected   any
+		expectedOk bool
+	}{
+		"string":         {field: "name", operand: "abc", expected: "abc", expectedOk: true},
+		"unsigned":       {field: "age", operand: "12", expected: uint64(12), expectedOk: true},
+		"unsigned error": {field: "age", operand: "-12"},
+		"float":          {field: "score", operand: "1.25", expected: 1.25, expectedOk: true},
+		"float error":    {field: "score", operand: "abc"},
+		"bool":           {field: "active", operand: "false", expected: false, expectedOk: true},
+		"bool error":     {field: "active", operand: "maybe"},
+		"date":           {field: "born", operand: "2020-02-03", expected: time.Date(2020, 2, 3, 0, 0, 0, 0, time.UTC), expectedOk: true},
+		"date and time":  {field: "born", operand: "2020-02-03 04:05:06", expected: time.Date(2020, 2, 3, 4, 5, 6, 0, time.UTC), expectedOk: true},
+		"date error":     {field: "born", operand: "yesterday"},
+		"unsupported":    {field: "tags", operand: "a"},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, ok := coerce(parsed.FieldsByDBName[testData.field], testData.operand)
+
+			// Assert
+			assert.Equal(t, testData.expectedOk, ok)
+
+			if testData.expectedOk {
+				assert.Equal(t, testData.expected, result)
+			}
+		})
+	}
+}
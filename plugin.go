@@ -1,7 +1,13 @@
 package gormlike
 
 import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 // Compile-time interface check
@@ -26,37 +32,290 @@ func TaggedOnly() Option {
 }
 
 // SettingOnly makes it so that only queries with the setting 'gormlike' set to true can be turned into LIKE queries.
-// This can be configured using db.Set("gormlike", true) on the query.
+// This can be configured using db.Set("gormlike", true) on the query, or the name given to WithName().
 func SettingOnly() Option {
 	return func(like *gormLike) {
 		like.conditionalSetting = true
 	}
 }
 
+// Tokenised splits filter values on whitespace and turns every word into its own %word% LIKE query, all of which
+// must match. Words between double quotes are kept together, so `"new york" john` results in 2 words. This only
+// applies to the columns given to WithTokenColumns() and to queries with the `gormlike:tokenise` setting, e.g.
+// db.Set("gormlike:tokenise", true), so that other queries on the same columns keep looking up exact values.
+func Tokenised() Option {
+	return func(like *gormLike) {
+		like.tokenised = true
+	}
+}
+
+// WithTokenColumns makes the words of a Tokenised() filter on the given column match any of the given columns as well,
+// e.g. WithTokenColumns("name", "city") allows `john amsterdam` on name to find John living in Amsterdam. Filters on
+// the given column are always tokenised, so WithTokenColumns("name") opts in just that column.
+func WithTokenColumns(column string, columns ...string) Option {
+	return func(like *gormLike) {
+		if like.tokenColumnMap == nil {
+			like.tokenColumnMap = map[string][]string{}
+		}
+
+		like.tokenColumnMap[column] = append(like.tokenColumnMap[column], columns...)
+	}
+}
+
+// Strict makes the query fail with an error like ErrFieldNotLikeable if a wildcard is requested on a column that
+// can't be turned into a LIKE query, instead of leaving the condition alone. Check the errors using errors.Is.
+func Strict() Option {
+	return func(like *gormLike) {
+		like.strict = true
+	}
+}
+
+// WithLogger logs which columns of a query were converted and which were skipped and why. As the logger of gorm
+// has no debug level, this is logged at info level.
+func WithLogger(gormLogger logger.Interface) Option {
+	return func(like *gormLike) {
+		like.logger = gormDecisionLogger{logger: gormLogger}
+	}
+}
+
+// WithSlogLogger logs which columns of a query were converted and which were skipped and why, at debug level.
+func WithSlogLogger(slogLogger *slog.Logger) Option {
+	return func(like *gormLike) {
+		like.logger = slogDecisionLogger{logger: slogLogger}
+	}
+}
+
+// WithObserver notifies the observer of every query that was turned into a LIKE query, with the converted columns
+// and their kind of pattern, how long the query took and how many rows it returned.
+func WithObserver(observer Observer) Option {
+	return func(like *gormLike) {
+		like.observer = observer
+	}
+}
+
+// LogIndexAdvice logs a warning for every LIKE query that can't use an index on its column, e.g. because the pattern
+// starts with a wildcard. The warnings go to the logger of WithLogger() or WithSlogLogger(), or the logger of gorm.
+func LogIndexAdvice() Option {
+	return func(like *gormLike) {
+		like.indexAdvice = true
+	}
+}
+
+// WithName registers the plugin under the given name instead of gormlike, which allows multiple differently configured
+// instances on the same database. The name is used for the callbacks and the setting, e.g. db.Set("search", true).
+func WithName(name string) Option {
+	return func(like *gormLike) {
+		like.name = name
+	}
+}
+
+// ForModels only converts queries on the given models, e.g. ForModels(&User{}). Combined with ForTables(), queries on
+// any of the models or tables are converted.
+func ForModels(models ...any) Option {
+	return func(like *gormLike) {
+		for _, model := range models {
+			modelType := reflect.TypeOf(model)
+			for modelType.Kind() == reflect.Ptr || modelType.Kind() == reflect.Slice || modelType.Kind() == reflect.Array {
+				modelType = modelType.Elem()
+			}
+
+			like.models = append(like.models, modelType)
+		}
+	}
+}
+
+// ForTables only converts queries on the given tables. Combined with ForModels(), queries on any of the models or
+// tables are converted.
+func ForTables(tables ...string) Option {
+	return func(like *gormLike) {
+		like.tables = append(like.tables, tables...)
+	}
+}
+
+// AllowColumns allows LIKE queries on columns that aren't fields of the model, like the columns of a query without a
+// model, e.g. db.Table("users"). Other columns are left alone, as their names might come from user input.
+func AllowColumns(columns ...string) Option {
+	return func(like *gormLike) {
+		like.allowedColumns = append(like.allowedColumns, columns...)
+	}
+}
+
+// WithOperators makes values starting with an operator compare the column instead, e.g. {"age": ">=18"}. These
+// operators are supported: >, >=, <, <=, ! (not equal, or NOT LIKE if the value has a wildcard) and ranges like
+// 18..65, 18.. and ..65. The value is converted to the type of the field, ranges are not supported on strings. Like
+// wildcards, operators are only used on fields that are likeable.
+func WithOperators() Option {
+	return func(like *gormLike) {
+		like.operators = true
+	}
+}
+
+// WithLimits leaves conditions alone if their patterns exceed the limits, or truncates the patterns if Limits.Truncate
+// is set. Combined with Strict(), exceeding a limit returns an error instead, e.g. ErrTooManyWildcards.
+func WithLimits(limits Limits) Option {
+	return func(like *gormLike) {
+		like.limits = limits
+	}
+}
+
+// RunBefore registers the callbacks that convert queries before the callback with the given name, e.g.
+// RunBefore("gormcase:query"), instead of just before gorm:query and gorm:row. The query callback has to be registered
+// already, otherwise Initialize returns ErrUnknownCallback. Row() queries are only ordered if the other plugin has a
+// row callback with the same name, as plugins often only register a query callback.
+func RunBefore(name string) Option {
+	return func(like *gormLike) {
+		like.before = name
+	}
+}
+
+// RunAfter registers the callbacks that convert queries after the callback with the given name, e.g.
+// RunAfter("deepgorm:query"). Like RunBefore(), the query callback has to be registered already.
+func RunAfter(name string) Option {
+	return func(like *gormLike) {
+		like.after = name
+	}
+}
+
+// ObserveBefore registers the callbacks that report to the Observer before the callback with the given name, e.g.
+// ObserveBefore("otel:after_query"), instead of anywhere after gorm:query and gorm:row. Like RunBefore(), the query
+// callback has to be registered already.
+func ObserveBefore(name string) Option {
+	return func(like *gormLike) {
+		like.observeBefore = name
+	}
+}
+
+// ObserveAfter registers the callbacks that report to the Observer after the callback with the given name, e.g.
+// ObserveAfter("gorm:after_query"), instead of just after gorm:query and gorm:row. Like RunBefore(), the query
+// callback has to be registered already.
+func ObserveAfter(name string) Option {
+	return func(like *gormLike) {
+		like.observeAfter = name
+	}
+}
+
 // New creates a new instance of the plugin that can be registered in gorm. Without any settings, all queries will be
 // LIKE-d.
 //
 //nolint:ireturn // Acceptable
 func New(opts ...Option) gorm.Plugin {
-	plugin := &gormLike{}
+	return newGormLike(opts...)
+}
+
+func newGormLike(opts ...Option) *gormLike {
+	plugin := &gormLike{name: tagName}
 
 	for _, opt := range opts {
 		opt(plugin)
 	}
 
+	plugin.startKey = plugin.name + ":start"
+
 	return plugin
 }
 
 type gormLike struct {
+	name               string
+	models             []reflect.Type
+	tables             []string
+	allowedColumns     []string
+	before             string
+	after              string
+	observeBefore      string
+	observeAfter       string
 	replaceCharacter   string
 	conditionalTag     bool
 	conditionalSetting bool
+	tokenised          bool
+	operators          bool
+	limits             Limits
+	strict             bool
+	logger             decisionLogger
+	observer           Observer
+	indexAdvice        bool
+	tokenColumnMap     map[string][]string
+
+	// startKey is the instance setting that the start of a query with wildcards is recorded in
+	startKey string
 }
 
 func (d *gormLike) Name() string {
-	return "gormlike"
+	return d.name
 }
 
 func (d *gormLike) Initialize(db *gorm.DB) error {
-	return db.Callback().Query().Before("gorm:query").Register("gormlike:query", d.queryCallback)
+	return register(db, d, d.queryCallback, d.observeCallback, d.observer != nil)
+}
+
+// applies returns whether the statement is on one of the models or tables given to ForModels() and ForTables()
+func (d *gormLike) applies(db *gorm.DB) bool {
+	if len(d.models) == 0 && len(d.tables) == 0 {
+		return true
+	}
+
+	if db.Statement.Schema != nil && slices.Contains(d.models, db.Statement.Schema.ModelType) {
+		return true
+	}
+
+	return slices.Contains(d.tables, db.Statement.Table)
+}
+
+// register adds the callbacks of the plugin to the query and row processors using the name and order of the
+// configuration, the observe callbacks are only added if the queries are observed
+func register(db *gorm.DB, config *gormLike, queryCallback, observeCallback func(*gorm.DB), observe bool) error {
+	name := config.name
+
+	queries := db.Callback().Query()
+
+	// Nothing is registered if any of the callbacks to order by is missing
+	orderedBy := []string{config.before, config.after}
+	if observe {
+		orderedBy = append(orderedBy, config.observeBefore, config.observeAfter)
+	}
+
+	if err := registered(queries.Get, orderedBy...); err != nil {
+		return err
+	}
+
+	if err := queries.Before(callbackOr(queries.Get, config.before, "gorm:query")).After(config.after).Register(name+":query", queryCallback); err != nil {
+		return err
+	}
+
+	// Row() and Scan() are often used for grouped queries, so these are converted as well
+	rows := db.Callback().Row()
+	if err := rows.Before(callbackOr(rows.Get, config.before, "gorm:row")).After(config.after).Register(name+":row", queryCallback); err != nil {
+		return err
+	}
+
+	if !observe {
+		return nil
+	}
+
+	if err := queries.Before(callbackOr(queries.Get, config.observeBefore, "")).After(callbackOr(queries.Get, config.observeAfter, "gorm:query")).Register(name+":observe_query", observeCallback); err != nil {
+		return err
+	}
+
+	return rows.Before(callbackOr(rows.Get, config.observeBefore, "")).After(callbackOr(rows.Get, config.observeAfter, "gorm:row")).Register(name+":observe_row", observeCallback)
+}
+
+// registered returns ErrUnknownCallback if one of the given query callbacks isn't registered, the order of the
+// callbacks would otherwise depend on the order in which the plugins are registered
+func registered(get func(name string) func(*gorm.DB), names ...string) error {
+	for _, name := range names {
+		if name != "" && get(name) == nil {
+			return fmt.Errorf("%w: %s", ErrUnknownCallback, name)
+		}
+	}
+
+	return nil
+}
+
+// callbackOr returns the given callback to register before or after, or the fallback if it isn't registered. Other
+// plugins often only register a query callback, which would leave the row callback unordered.
+func callbackOr(get func(name string) func(*gorm.DB), name, fallback string) string {
+	if name != "" && get(name) != nil {
+		return name
+	}
+
+	return fallback
 }
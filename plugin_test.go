@@ -6,6 +6,7 @@ import (
 	"github.com/ing-bank/gormtestutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func TestDeepGorm_Name_ReturnsExpectedName(t *testing.T) {
@@ -32,4 +33,118 @@ func TestDeepGorm_Initialize_RegistersCallback(t *testing.T) {
 	// Assert
 	require.NoError(t, err)
 	assert.NotNil(t, db.Callback().Query().Get("gormlike:query"))
+	assert.NotNil(t, db.Callback().Row().Get("gormlike:row"))
+}
+
+func TestDeepGorm_Initialize_RegistersObserverCallbacks(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t)
+	plugin := New(WithObserver(&MemoryObserver{}))
+
+	// Act
+	err := plugin.Initialize(db)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotNil(t, db.Callback().Query().Get("gormlike:observe_query"))
+	assert.NotNil(t, db.Callback().Row().Get("gormlike:observe_row"))
+}
+
+func TestDeepGorm_Initialize_RegistersNamedCallbacks(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	db := gormtestutil.NewMemoryDatabase(t)
+	plugin := New(WithName("search"), WithObserver(&MemoryObserver{}))
+
+	// Act
+	err := plugin.Initialize(db)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "search", plugin.Name())
+	assert.NotNil(t, db.Callback().Query().Get("search:query"))
+	assert.NotNil(t, db.Callback().Row().Get("search:row"))
+	assert.NotNil(t, db.Callback().Query().Get("search:observe_query"))
+	assert.NotNil(t, db.Callback().Row().Get("search:observe_row"))
+	assert.Nil(t, db.Callback().Query().Get("gormlike:query"))
+}
+
+type ObjectR struct {
+	ID   int
+	Name string `gormlike:"true"`
+	Code string
+}
+
+type ObjectS struct {
+	ID   int
+	Name string
+}
+
+func TestDeepGorm_Initialize_AppliesNamedInstancesToTheirModels(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		query func(*gorm.DB) *gorm.DB
+
+		expected string
+	}{
+		"tagged model, tagged field": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&ObjectR{}).Where(map[string]any{"name": "j%"})
+			},
+			expected: "SELECT * FROM `object_rs` WHERE CAST(`object_rs`.`name` as varchar) LIKE ?",
+		},
+		"tagged model, untagged field": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&ObjectR{}).Where(map[string]any{"code": "j%"})
+			},
+			expected: "SELECT * FROM `object_rs` WHERE `object_rs`.`code` = ?",
+		},
+		"tagged model, setting of the other instance": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Set("setting", true).Model(&ObjectR{}).Where(map[string]any{"code": "j%"})
+			},
+			expected: "SELECT * FROM `object_rs` WHERE `object_rs`.`code` = ?",
+		},
+		"setting table without setting": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&ObjectS{}).Where(map[string]any{"name": "j%"})
+			},
+			expected: "SELECT * FROM `object_s` WHERE `object_s`.`name` = ?",
+		},
+		"setting table with setting": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Set("setting", true).Model(&ObjectS{}).Where(map[string]any{"name": "j%"})
+			},
+			expected: "SELECT * FROM `object_s` WHERE CAST(`object_s`.`name` as varchar) LIKE ?",
+		},
+		"setting table with default setting": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Set("gormlike", true).Model(&ObjectS{}).Where(map[string]any{"name": "j%"})
+			},
+			expected: "SELECT * FROM `object_s` WHERE `object_s`.`name` = ?",
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+			// Act
+			errTagged := db.Use(New(WithName("tagged"), TaggedOnly(), ForModels(&ObjectR{})))
+			errSetting := db.Use(New(WithName("setting"), SettingOnly(), ForTables("object_s")))
+
+			// Assert
+			require.NoError(t, errTagged)
+			require.NoError(t, errSetting)
+
+			query := testData.query(db.Session(&gorm.Session{DryRun: true})).Find(&[]map[string]any{})
+			require.NoError(t, query.Error)
+
+			assert.Equal(t, testData.expected, query.Statement.SQL.String())
+		})
+	}
 }
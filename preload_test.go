@@ -0,0 +1,202 @@
+package gormlike
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type Badge struct {
+	ID     int
+	Name   string
+	Hidden string `gormlike:"false"`
+}
+
+type Member struct {
+	ID     int
+	Badges []Badge `gorm:"many2many:member_badges"`
+}
+
+// MemberBadge is a join table with a column that also exists in badges
+type MemberBadge struct {
+	MemberID int `gorm:"primaryKey"`
+	BadgeID  int `gorm:"primaryKey"`
+	Name     string
+}
+
+//nolint:maintidx // Acceptable
+func TestGormLike_Initialize_TriggersLikingInPreloadsAndAssociationsCorrectly(t *testing.T) {
+	t.Parallel()
+
+	orderIDs := func(employees []Employee) []int {
+		result := []int{}
+		for _, employee := range employees {
+			for _, order := range employee.Orders {
+				result = append(result, order.ID)
+			}
+		}
+
+		return result
+	}
+
+	tests := map[string]struct {
+		options  []Option
+		query    func(*gorm.DB) ([]int, error)
+		expected []int
+	}{
+		"preload with conditions": {
+			query: func(db *gorm.DB) ([]int, error) {
+				var employees []Employee
+				err := db.Preload("Orders", map[string]any{"reference": "INV%"}).Find(&employees).Error
+
+				return orderIDs(employees), err
+			},
+			expected: []int{1, 3},
+		},
+		"preload with scope": {
+			query: func(db *gorm.DB) ([]int, error) {
+				var employees []Employee
+				err := db.Preload("Orders", func(tx *gorm.DB) *gorm.DB {
+					return tx.Where(map[string]any{"reference": []string{"QUO%", "INV-3"}})
+				}).Find(&employees).Error
+
+				return orderIDs(employees), err
+			},
+			expected: []int{2, 3},
+		},
+		"preload with setting enabled on parent": {
+			options: []Option{SettingOnly()},
+			query: func(db *gorm.DB) ([]int, error) {
+				var employees []Employee
+				err := db.Set(tagName, true).Preload("Orders", map[string]any{"reference": "INV%"}).Find(&employees).Error
+
+				return orderIDs(employees), err
+			},
+			expected: []int{1, 3},
+		},
+		"preload without setting on parent": {
+			options: []Option{SettingOnly()},
+			query: func(db *gorm.DB) ([]int, error) {
+				var employees []Employee
+				err := db.Preload("Orders", map[string]any{"reference": "INV%"}).Find(&employees).Error
+
+				return orderIDs(employees), err
+			},
+			expected: []int{},
+		},
+		"preload with setting disabled on parent": {
+			query: func(db *gorm.DB) ([]int, error) {
+				var employees []Employee
+				err := db.Set(tagName, false).Preload("Orders", map[string]any{"reference": "INV%"}).Find(&employees).Error
+
+				return orderIDs(employees), err
+			},
+			expected: []int{},
+		},
+		"preload respects tags of the preloaded model": {
+			query: func(db *gorm.DB) ([]int, error) {
+				var employees []Employee
+				err := db.Preload("Company", map[string]any{"secret": "%b%"}).Find(&employees).Error
+
+				var result []int
+				for _, employee := range employees {
+					if employee.Company != nil {
+						result = append(result, employee.Company.ID)
+					}
+				}
+
+				return result, err
+			},
+			expected: nil,
+		},
+		"association find": {
+			query: func(db *gorm.DB) ([]int, error) {
+				var orders []Order
+				err := db.Model(&Employee{ID: 1}).Association("Orders").Find(&orders, map[string]any{"reference": "%-1"})
+
+				var result []int
+				for _, order := range orders {
+					result = append(result, order.ID)
+				}
+
+				return result, err
+			},
+			expected: []int{1, 2},
+		},
+		"association find with setting disabled": {
+			query: func(db *gorm.DB) ([]int, error) {
+				var orders []Order
+				err := db.Set(tagName, false).Model(&Employee{ID: 1}).Association("Orders").Find(&orders, map[string]any{"reference": "%-1"})
+
+				result := []int{}
+				for _, order := range orders {
+					result = append(result, order.ID)
+				}
+
+				return result, err
+			},
+			expected: []int{},
+		},
+		"many to many association find with the same column in the join table": {
+			query: func(db *gorm.DB) ([]int, error) {
+				var badges []Badge
+				err := db.Model(&Member{ID: 1}).Association("Badges").Find(&badges, map[string]any{"name": "G%"})
+
+				var result []int
+				for _, badge := range badges {
+					result = append(result, badge.ID)
+				}
+
+				return result, err
+			},
+			expected: []int{1},
+		},
+		"many to many association find respects tags": {
+			query: func(db *gorm.DB) ([]int, error) {
+				var badges []Badge
+				err := db.Model(&Member{ID: 1}).Association("Badges").Find(&badges, map[string]any{"hidden": "%"})
+
+				result := []int{}
+				for _, badge := range badges {
+					result = append(result, badge.ID)
+				}
+
+				return result, err
+			},
+			expected: []int{},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			require.NoError(t, db.SetupJoinTable(&Member{}, "Badges", &MemberBadge{}))
+			_ = db.AutoMigrate(&Company{}, &Address{}, &Order{}, &Skill{}, &Comment{}, &Employee{}, &Badge{}, &Member{})
+
+			employees := []Employee{
+				{ID: 1, Company: &Company{ID: 1, Name: "Acme BV", Secret: "abc"}, Orders: []Order{{ID: 1, Reference: "INV-1"}, {ID: 2, Reference: "QUO-1"}}},
+				{ID: 2, Company: &Company{ID: 2, Name: "Initech", Secret: "def"}, Orders: []Order{{ID: 3, Reference: "INV-3"}}},
+			}
+			require.NoError(t, db.Create(&employees).Error)
+
+			member := Member{ID: 1, Badges: []Badge{{ID: 1, Name: "Gold", Hidden: "yes"}, {ID: 2, Name: "Silver", Hidden: "no"}}}
+			require.NoError(t, db.Create(&member).Error)
+
+			// Act
+			err := db.Use(New(testData.options...))
+
+			// Assert
+			require.NoError(t, err)
+
+			actual, err := testData.query(db)
+			require.NoError(t, err)
+
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
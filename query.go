@@ -2,92 +2,389 @@ package gormlike
 
 import (
 	"fmt"
+	"reflect"
+	"slices"
 	"strings"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
 const tagName = "gormlike"
 
+// isLikeable returns whether a field with the given `gormlike` tag value may be turned into a LIKE query
+func (d *gormLike) isLikeable(tagValue string) bool {
+	// If the user has explicitly set this to false, ignore this field
+	if tagValue == "false" {
+		return false
+	}
+
+	// If tags are required and the tag is not true, ignore this field
+	return !d.conditionalTag || tagValue == "true"
+}
+
+// target is whatever a condition is applied to, like a column or a path in a JSON column
+type target struct {
+	// field is the schema field whose tags decide whether the target is likeable, nil if unknown
+	field *schema.Field
+
+	// condition returns the SQL of a condition on the target with a single ?, either a LIKE or an equality check
+	condition func(how comparison) string
+
+	// nested is whether the target is inside another column or table, like a field of an association or a path in a
+	// JSON column, which GORM can't compare with as is
+	nested bool
+}
+
+// policy returns what may be done with the target's field, an empty policy if the field is unknown
+func (t target) policy() fieldPolicy {
+	return policyOf(t.field)
+}
+
+// comparison returns how the target is compared with patterns, caseInsensitive overrides the settings of the field
+func (t target) comparison(caseInsensitive bool) comparison {
+	if caseInsensitive {
+		return compareLikeLower
+	}
+
+	return t.policy().comparison()
+}
+
+// comparison is how a target is compared with a value
+type comparison int
+
+const (
+	// compareEqual checks whether the target equals the value
+	compareEqual comparison = iota
+
+	// compareLike checks whether the target matches the pattern
+	compareLike
+
+	// compareLikeLower checks whether the target matches the pattern, regardless of case
+	compareLikeLower
+)
+
+// compare returns the SQL that compares the expression with a single ?
+func compare(expression string, how comparison) string {
+	switch how {
+	case compareLike:
+		return expression + " LIKE ?"
+	case compareLikeLower:
+		return "LOWER(" + expression + ") LIKE LOWER(?)"
+	default:
+		return expression + " = ?"
+	}
+}
+
+// fieldTag is the parsed `gormlike` tag of a field, e.g. `gormlike:"true;match:prefix"`
+type fieldTag struct {
+	// value is either true, false or empty
+	value string
+
+	// match is the only kind of pattern the field may be queried with, e.g. prefix
+	match string
+}
+
+// parseTag parses a `gormlike` tag, which starts with its value and may be followed by settings like match:prefix
+func parseTag(tag string) fieldTag {
+	var result fieldTag
+
+	for index, part := range strings.Split(tag, ";") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), ":")
+
+		switch {
+		case !found && index == 0:
+			result.value = key
+		case strings.EqualFold(key, "match"):
+			result.match = strings.ToLower(strings.TrimSpace(value))
+		}
+	}
+
+	return result
+}
+
+// source is the table that columns using clause.CurrentTable in a set of expressions belong to
+type source struct {
+	// schema is the schema of the table, nil if unknown
+	schema *schema.Schema
+
+	// table is the name or alias of the table in the query
+	table string
+}
+
+// tableSchema returns the schema of a table or join alias in the statement, nil if unknown
+func tableSchema(db *gorm.DB, table string) *schema.Schema {
+	statementSchema := db.Statement.Schema
+	if statementSchema == nil {
+		return nil
+	}
+
+	if table == db.Statement.Table || table == statementSchema.Table {
+		return statementSchema
+	}
+
+	// Joins added through clause.From might use an alias for a table
+	if from, ok := db.Statement.Clauses["FROM"].Expression.(clause.From); ok {
+		for _, join := range from.Joins {
+			if join.Table.Alias == table {
+				table = join.Table.Name
+			}
+		}
+	}
+
+	// Joins on associations use the name of the association as an alias
+	for name, relationship := range statementSchema.Relationships.Relations {
+		if name == table || relationship.FieldSchema.Table == table {
+			return relationship.FieldSchema
+		}
+	}
+
+	return nil
+}
+
+// resolveTarget figures out what the given column refers to, returns false if it can't be used in a LIKE query
+func (d *gormLike) resolveTarget(db *gorm.DB, src source, column clause.Column) (target, bool) {
+	schemaValue, table := src.schema, column.Table
+
+	switch column.Table {
+	case "":
+	case clause.CurrentTable:
+		table = src.table
+	default:
+		schemaValue = tableSchema(db, column.Table)
+	}
+
+	name := column.Name
+
+	var dbField *schema.Field
+	if schemaValue != nil {
+		dbField = schemaValue.FieldsByDBName[name]
+	}
+
+	// Names like users.name refer to a column of a table, which goes before associations and paths in JSON columns
+	if dbField == nil {
+		if qualifiedSchema, qualifiedField, qualifiedTable, ok := qualifiedColumn(db, name); ok {
+			schemaValue, dbField, table, name = qualifiedSchema, qualifiedField, qualifiedTable, qualifiedField.DBName
+		}
+	}
+
+	// The name might come from user input, so columns that aren't fields are only used if they're allowed explicitly
+	if dbField == nil && !strings.Contains(name, ".") && !slices.Contains(d.allowedColumns, name) {
+		return target{}, false
+	}
+
+	return resolveFieldTarget(db, schemaValue, dbField, table, name)
+}
+
+// qualifiedColumn splits a name like users.name into the schema and field of the table or alias and the column,
+// returns false if the name doesn't start with a table of the query. Associations that aren't joined are left to
+// resolveFieldTarget, as Company.Name refers to a field of the association instead.
+func qualifiedColumn(db *gorm.DB, name string) (*schema.Schema, *schema.Field, string, bool) {
+	prefix, rest, ok := strings.Cut(name, ".")
+	if !ok || db.Statement.Schema == nil {
+		return nil, nil, "", false
+	}
+
+	if _, isRelation := db.Statement.Schema.Relationships.Relations[prefix]; isRelation && !joined(db, prefix) {
+		return nil, nil, "", false
+	}
+
+	schemaValue := tableSchema(db, prefix)
+	if schemaValue == nil {
+		return nil, nil, "", false
+	}
+
+	field := schemaValue.FieldsByDBName[rest]
+	if field == nil {
+		return nil, nil, "", false
+	}
+
+	return schemaValue, field, prefix, true
+}
+
+// joined returns whether the association with the given name is joined in the query
+func joined(db *gorm.DB, name string) bool {
+	for _, join := range db.Statement.Joins {
+		if join.Name == name || join.Alias == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// quoteColumn returns the quoted name of the column, prefixed with the table if one is given
+func quoteColumn(db *gorm.DB, table, name string) string {
+	return db.Statement.Quote(clause.Column{Table: table, Name: name})
+}
+
+// resolveFieldTarget returns the target for a column in the given schema, dbField is nil if the column is not a field.
+// The table is used to qualify the column, as the condition might end up in a query with joins.
+func resolveFieldTarget(db *gorm.DB, schemaValue *schema.Schema, dbField *schema.Field, table, name string) (target, bool) {
+	// A column that isn't a field in the schema, but contains a '.' might be an association or a path in a JSON column
+	if dbField == nil && strings.Contains(name, ".") {
+		if schemaValue == nil {
+			return target{}, false
+		}
+
+		prefix, rest, _ := strings.Cut(name, ".")
+		if relationship, ok := schemaValue.Relationships.Relations[prefix]; ok {
+			return resolveAssociationTarget(db, relationship, table, rest)
+		}
+
+		return resolveJSONTarget(db, schemaValue, table, name)
+	}
+
+	// Arrays are matched if any of their elements match
+	if dbField != nil && (isNativeArrayField(dbField) || isJSONArrayField(dbField)) {
+		return resolveArrayTarget(db, dbField, table)
+	}
+
+	quotedColumn := quoteColumn(db, table, name)
+
+	result := target{
+		field: dbField,
+		condition: func(how comparison) string {
+			if how == compareEqual {
+				return compare(quotedColumn, how)
+			}
+
+			return compare(likeExpression(db.Dialector.Name(), quotedColumn, dbField), how)
+		},
+	}
+
+	return result, true
+}
+
+// likeExpression returns the expression that the column is matched with. Columns are cast to text so that any type
+// can be matched, except string columns on MySQL, as MySQL can't use an index on a column that is cast.
+func likeExpression(dialect, quotedColumn string, field *schema.Field) string {
+	if dialect == "mysql" && field != nil && field.DataType == schema.String {
+		return quotedColumn
+	}
+
+	return fmt.Sprintf("CAST(%s as %s)", quotedColumn, textType(dialect))
+}
+
+// likeableTarget resolves the target of a column that a wildcard was requested on, returns false if it can't or
+// may not be turned into a LIKE query
+func (d *gormLike) likeableTarget(db *gorm.DB, src source, column clause.Column) (target, bool) {
+	result, ok := d.resolveTarget(db, src, column)
+
+	switch {
+	case !ok:
+		d.skip(db, src, column, ReasonUnknownField)
+	case !d.isLikeable(result.policy().value) && result.field == nil:
+		d.skip(db, src, column, ReasonUnknownField)
+	case !d.isLikeable(result.policy().value):
+		d.skip(db, src, column, ReasonFieldNotLikeable)
+	default:
+		return result, true
+	}
+
+	return target{}, false
+}
+
 //nolint:gocognit,cyclop // is a complex, recursive function
-func (d *gormLike) replaceExpressions(db *gorm.DB, expressions []clause.Expression) []clause.Expression {
-	for index, cond := range expressions {
-		switch cond := cond.(type) {
+func (d *gormLike) replaceExpressions(db *gorm.DB, src source, expressions []clause.Expression) []clause.Expression {
+	// The expressions might be shared with other statements, so we don't want to modify them
+	expressions = slices.Clone(expressions)
+
+	for index := range expressions {
+		expressions[index] = d.adoptSubqueries(db, expressions[index])
+
+		switch cond := expressions[index].(type) {
 		case clause.AndConditions:
 			// Recursively go through the expressions of AndConditions
-			cond.Exprs = d.replaceExpressions(db, cond.Exprs)
+			cond.Exprs = d.replaceExpressions(db, src, cond.Exprs)
 			expressions[index] = cond
 		case clause.OrConditions:
 			// Recursively go through the expressions of OrConditions
-			cond.Exprs = d.replaceExpressions(db, cond.Exprs)
+			cond.Exprs = d.replaceExpressions(db, src, cond.Exprs)
+			expressions[index] = cond
+		case clause.Where:
+			// Other plugins replace conditions with the WHERE clause of a new session, e.g. gormcase and deepgorm
+			cond.Exprs = d.replaceExpressions(db, src, cond.Exprs)
 			expressions[index] = cond
 		case clause.Eq:
-			column, columnOk := cond.Column.(clause.Column)
-			if !columnOk {
-				continue
+			expression, ok := d.replaceOperator(db, src, cond)
+			if !ok {
+				expression, ok = d.replaceEq(db, src, cond, false)
 			}
 
-			// Get the `gormlike` value
-			var tagValue string
-			dbField, ok := db.Statement.Schema.FieldsByDBName[column.Name]
-			if ok {
-				tagValue = dbField.Tag.Get(tagName)
+			if ok && expression != nil {
+				expressions[index] = expression
 			}
+		case clause.Expr:
+			// Conditions made case-insensitive by gormcase, e.g. LOWER(name) = LOWER(?)
+			if eq, ok := caseInsensitiveEq(cond); ok {
+				if expression, ok := d.replaceEq(db, src, eq, true); ok {
+					expressions[index] = expression
+				}
+			}
+		case clause.IN:
+			var likeCounter int
 
-			// If the user has explicitly set this to false, ignore this field
-			if tagValue == "false" {
-				continue
+			for _, value := range cond.Values {
+				stringValue, valueOk := value.(string)
+
+				switch {
+				case valueOk && d.hasWildcard(stringValue):
+					likeCounter++
+				case !valueOk && d.wantsUnsupportedLike(value, false):
+					d.skip(db, src, cond.Column, ReasonUnsupportedValue)
+				}
 			}
 
-			// If tags are required and the tag is not true, ignore this field
-			if d.conditionalTag && tagValue != "true" {
+			// Don't alter the query if it isn't necessary
+			if likeCounter == 0 {
+				d.skip(db, src, cond.Column, ReasonNoWildcard)
+
+				if expression, ok := d.replaceExact(db, src, cond.Column, cond.Values); ok {
+					expressions[index] = expression
+				}
+
 				continue
 			}
 
-			value, columnOk := cond.Value.(string)
+			column, columnOk := cond.Column.(clause.Column)
 			if !columnOk {
+				d.skip(db, src, cond.Column, ReasonUnsupportedColumn)
+
 				continue
 			}
 
-			// If there are no % AND there aren't only replaceable characters, just skip it because it's a normal query
-			if !strings.Contains(value, "%") && (d.replaceCharacter == "" || !strings.Contains(value, d.replaceCharacter)) {
+			target, targetOk := d.likeableTarget(db, src, column)
+			if !targetOk {
 				continue
 			}
 
-			condition := fmt.Sprintf("CAST(%s as varchar) LIKE ?", column.Name)
+			policy := target.policy()
 
-			if d.replaceCharacter != "" {
-				value = strings.ReplaceAll(value, d.replaceCharacter, "%")
-			}
+			// All values are converted at once, so if any of the patterns isn't allowed the condition is left alone
+			var patterns []string
 
-			expressions[index] = db.Session(&gorm.Session{NewDB: true}).Where(condition, value).Statement.Clauses["WHERE"].Expression
-		case clause.IN:
-			column, columnOk := cond.Column.(clause.Column)
-			if !columnOk {
-				continue
+			for _, value := range cond.Values {
+				if value, valueOk := value.(string); valueOk && d.hasWildcard(value) {
+					patterns = append(patterns, d.replaceWildcards(value))
+				}
 			}
 
-			// Get the `gormlike` value
-			var tagValue string
-			dbField, ok := db.Statement.Schema.FieldsByDBName[column.Name]
-			if ok {
-				tagValue = dbField.Tag.Get(tagName)
-			}
+			patterns, reason := d.limitPatterns(patterns, false)
+			if reason != "" {
+				d.skip(db, src, column, reason)
 
-			// If the user has explicitly set this to false, ignore this field
-			if tagValue == "false" {
 				continue
 			}
 
-			// If tags are required and the tag is not true, ignore this field
-			if d.conditionalTag && tagValue != "true" {
+			if !policy.allows(patterns...) {
+				d.skip(db, src, column, ReasonPatternNotAllowed)
+
 				continue
 			}
 
-			var likeCounter int
-
-			query := db.Session(&gorm.Session{NewDB: true})
+			conditions := make([]clause.Expression, 0, len(cond.Values))
 
 			for _, value := range cond.Values {
 				value, valueOk := value.(string)
@@ -95,66 +392,249 @@ func (d *gormLike) replaceExpressions(db *gorm.DB, expressions []clause.Expressi
 					continue
 				}
 
-				condition := fmt.Sprintf("%s = ?", column.Name)
+				condition := target.condition(compareEqual)
 
 				// If there are no % AND there aren't only replaceable characters, just skip it because it's a normal query
-				if strings.Contains(value, "%") || (d.replaceCharacter != "" && strings.Contains(value, d.replaceCharacter)) {
-					condition = fmt.Sprintf("CAST(%s as varchar) LIKE ?", column.Name)
-
-					if d.replaceCharacter != "" {
-						value = strings.ReplaceAll(value, d.replaceCharacter, "%")
-					}
+				if d.hasWildcard(value) {
+					condition = target.condition(policy.comparison())
+					// The limited patterns are in the same order as the values with wildcards
+					value, patterns = patterns[0], patterns[1:]
 
-					likeCounter++
+					d.converted(db, src, column, condition, value)
 				}
 
-				query = query.Or(condition, value)
+				conditions = append(conditions, clause.Expr{SQL: condition, Vars: []any{value}})
 			}
 
-			// Don't alter the query if it isn't necessary
-			if likeCounter == 0 {
-				continue
-			}
+			expressions[index] = orExpression(conditions)
+		}
+	}
 
-			whereExpression, ok := query.Statement.Clauses["WHERE"].Expression.(clause.Where)
+	return expressions
+}
 
-			if ok {
-				expressions[index] = whereExpression
-			}
+// replaceEq turns the condition into a LIKE condition if it has a wildcard and its column is likeable, caseInsensitive
+// makes it a case-insensitive LIKE condition regardless of the settings of the column
+func (d *gormLike) replaceEq(db *gorm.DB, src source, cond clause.Eq, caseInsensitive bool) (clause.Expression, bool) {
+	tokenise := d.tokenises(db, cond.Column)
 
-			// This feels a bit like a dirty hack
-			// but otherwise the generated query would not be correct in case of an AND condition between multiple OR conditions
-			// e.g. without this -> x = .. OR x = .. AND y = .. OR y = .. (no brackets around the OR conditions mess up the query)
-			// e.g. with this -> (x = .. OR x = ..) AND (y = .. OR y = ..)
-			if len(whereExpression.Exprs) > 1 {
-				var newExpression clause.OrConditions
-				newExpression.Exprs = whereExpression.Exprs
+	value, valueOk := cond.Value.(string)
+	if !valueOk {
+		if d.wantsUnsupportedLike(cond.Value, tokenise) {
+			d.skip(db, src, cond.Column, ReasonUnsupportedValue)
 
-				expressions[index] = newExpression
-			}
+			return nil, false
+		}
+
+		d.skip(db, src, cond.Column, ReasonNoWildcard)
+
+		if !maybeNested(cond.Column) {
+			return nil, false
 		}
+
+		return d.replaceExact(db, src, cond.Column, []any{cond.Value})
 	}
 
-	return expressions
+	// If there are no % AND there aren't only replaceable characters, just skip it because it's a normal query
+	if !d.wantsLike(value, tokenise) {
+		d.skip(db, src, cond.Column, ReasonNoWildcard)
+
+		if caseInsensitive || !maybeNested(cond.Column) {
+			return nil, false
+		}
+
+		return d.replaceExact(db, src, cond.Column, []any{cond.Value})
+	}
+
+	column, columnOk := cond.Column.(clause.Column)
+	if !columnOk {
+		d.skip(db, src, cond.Column, ReasonUnsupportedColumn)
+
+		return nil, false
+	}
+
+	target, targetOk := d.likeableTarget(db, src, column)
+	if !targetOk {
+		return nil, false
+	}
+
+	policy := target.policy()
+
+	// In tokenised mode every word in the value is searched for separately
+	if tokenise {
+		patterns, reason := d.limitPatterns(d.tokenPatterns(value), true)
+
+		switch {
+		case reason != "":
+			d.skip(db, src, column, reason)
+		case len(patterns) == 0:
+			d.skip(db, src, column, ReasonNoWildcard)
+		case !policy.allows(patterns...):
+			d.skip(db, src, column, ReasonPatternNotAllowed)
+		default:
+			return d.tokenExpression(db, src, column, target, patterns, caseInsensitive), true
+		}
+
+		return nil, false
+	}
+
+	value, reason := d.limitPattern(d.replaceWildcards(value))
+	if reason != "" {
+		d.skip(db, src, column, reason)
+
+		return nil, false
+	}
+
+	if !policy.allows(value) {
+		d.skip(db, src, column, ReasonPatternNotAllowed)
+
+		return nil, false
+	}
+
+	condition := target.condition(target.comparison(caseInsensitive))
+	d.converted(db, src, column, condition, value)
+
+	return clause.Expr{SQL: condition, Vars: []any{value}}, true
+}
+
+// replaceExact turns a condition on exact values into equality checks if its column is nested, like `Company.Name`,
+// as GORM would use it as a column of the table. Other columns are left alone.
+func (d *gormLike) replaceExact(db *gorm.DB, src source, column any, values []any) (clause.Expression, bool) {
+	clauseColumn, ok := column.(clause.Column)
+	if !ok || !maybeNested(clauseColumn) || len(values) == 0 {
+		return nil, false
+	}
+
+	if slices.ContainsFunc(values, func(value any) bool { return !isScalar(value) }) {
+		return nil, false
+	}
+
+	target, ok := d.resolveTarget(db, src, clauseColumn)
+	if !ok || !target.nested {
+		return nil, false
+	}
+
+	conditions := make([]clause.Expression, 0, len(values))
+	for _, value := range values {
+		conditions = append(conditions, clause.Expr{SQL: target.condition(compareEqual), Vars: []any{value}})
+	}
+
+	return orExpression(conditions), true
+}
+
+// maybeNested returns whether the column might be nested, which saves resolving columns that can't be
+func maybeNested(column any) bool {
+	clauseColumn, ok := column.(clause.Column)
+
+	return ok && strings.Contains(clauseColumn.Name, ".")
+}
+
+// isScalar returns whether the value is a single value that can be compared with, like a string or a number
+func isScalar(value any) bool {
+	switch reflect.Indirect(reflect.ValueOf(value)).Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// orExpression combines the expressions using OR. An OrConditions with multiple expressions puts itself between
+// parentheses, which keeps an AND between multiple of them intact, e.g. (x = .. OR x = ..) AND (y = .. OR y = ..).
+// A single expression is returned as is, because GORM would join an OrConditions of one expression using OR.
+func orExpression(expressions []clause.Expression) clause.Expression {
+	if len(expressions) == 1 {
+		return expressions[0]
+	}
+
+	return clause.OrConditions{Exprs: expressions}
 }
 
 func (d *gormLike) queryCallback(db *gorm.DB) {
+	if bypassed(db) || !d.applies(db) {
+		return
+	}
+
+	if d.collecting(db) {
+		db.InstanceSet(decisionsKey(d.name), &[]Decision{})
+		defer d.startObservation(db)
+		defer d.logIndexAdvice(db)
+		defer d.logDecisions(db)
+	}
+
 	// If we only want to like queries that are explicitly set to true, we back out early if anything's amiss
-	settingValue, settingOk := db.Get(tagName)
+	settingValue, settingOk := db.Get(d.name)
 	if d.conditionalSetting && !settingOk {
+		d.record(db, Decision{Table: db.Statement.Table, Reason: ReasonSettingDisabled})
+
 		return
 	}
 
 	if settingOk {
 		if boolValue, _ := settingValue.(bool); !boolValue {
+			d.record(db, Decision{Table: db.Statement.Table, Reason: ReasonSettingDisabled})
+
 			return
 		}
 	}
 
-	exp, settingOk := db.Statement.Clauses["WHERE"].Expression.(clause.Where)
-	if !settingOk {
-		return
+	statementSource := source{schema: db.Statement.Schema, table: clause.CurrentTable}
+
+	// Subqueries used as a table, e.g. db.Table("(?) AS users", subquery)
+	if tableExpr, ok := d.adoptSubqueries(db, db.Statement.TableExpr).(*clause.Expr); ok {
+		db.Statement.TableExpr = tableExpr
+	}
+
+	if whereClause, ok := db.Statement.Clauses["WHERE"]; ok {
+		if where, ok := whereClause.Expression.(clause.Where); ok {
+			where.Exprs = d.replaceExpressions(db, statementSource, where.Exprs)
+			whereClause.Expression = where
+			db.Statement.Clauses["WHERE"] = whereClause
+		}
+	}
+
+	if groupByClause, ok := db.Statement.Clauses["GROUP BY"]; ok {
+		if groupBy, ok := groupByClause.Expression.(clause.GroupBy); ok {
+			groupBy.Having = d.replaceExpressions(db, statementSource, groupBy.Having)
+			groupByClause.Expression = groupBy
+			db.Statement.Clauses["GROUP BY"] = groupByClause
+		}
 	}
 
-	exp.Exprs = d.replaceExpressions(db, exp.Exprs)
+	// Joins added through clause.From, columns of joined tables are resolved through tableSchema
+	if fromClause, ok := db.Statement.Clauses["FROM"]; ok {
+		if from, ok := fromClause.Expression.(clause.From); ok {
+			from.Joins = slices.Clone(from.Joins)
+
+			for index := range from.Joins {
+				from.Joins[index].ON.Exprs = d.replaceExpressions(db, statementSource, from.Joins[index].ON.Exprs)
+			}
+
+			fromClause.Expression = from
+			db.Statement.Clauses["FROM"] = fromClause
+		}
+	}
+
+	// Joins on associations with conditions, e.g. db.Joins("Company", db.Where(...)), are only turned into clauses
+	// when the query is built. Their columns belong to the association, which is aliased using its name.
+	for index, join := range db.Statement.Joins {
+		if join.On == nil || db.Statement.Schema == nil {
+			continue
+		}
+
+		relationship, ok := db.Statement.Schema.Relationships.Relations[join.Name]
+		if !ok {
+			continue
+		}
+
+		joinSource := source{schema: relationship.FieldSchema, table: join.Name}
+		if join.Alias != "" {
+			joinSource.table = join.Alias
+		}
+
+		db.Statement.Joins[index].On = &clause.Where{Exprs: d.replaceExpressions(db, joinSource, join.On.Exprs)}
+	}
 }
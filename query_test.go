@@ -1,6 +1,7 @@
 package gormlike
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/google/uuid"
@@ -8,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 //nolint:maintidx // Acceptable
@@ -385,6 +387,91 @@ func TestGormLike_Initialize_TriggersLikingCorrectly(t *testing.T) {
 	}
 }
 
+func TestGormLike_Initialize_KeepsParenthesesAroundOrConditions(t *testing.T) {
+	t.Parallel()
+
+	type ObjectP struct {
+		ID    int
+		Name  string
+		Other string
+	}
+
+	tests := map[string]struct {
+		filter  map[string]any
+		options []Option
+
+		expectedSQL  string
+		expectedVars []any
+		expectedIDs  []int
+	}{
+		"single wildcard value": {
+			filter: map[string]any{
+				"name":  []string{"j%"},
+				"other": "x",
+			},
+			expectedSQL:  "SELECT * FROM `object_ps` WHERE CAST(`object_ps`.`name` as varchar) LIKE ? AND `object_ps`.`other` = ?",
+			expectedVars: []any{"j%", "x"},
+			expectedIDs:  []int{1},
+		},
+		"and between or conditions": {
+			filter: map[string]any{
+				"name":  []string{"j%", "amy"},
+				"other": []string{"%x", "%z"},
+			},
+			expectedSQL:  "SELECT * FROM `object_ps` WHERE (CAST(`object_ps`.`name` as varchar) LIKE ? OR `object_ps`.`name` = ?) AND (CAST(`object_ps`.`other` as varchar) LIKE ? OR CAST(`object_ps`.`other` as varchar) LIKE ?)",
+			expectedVars: []any{"j%", "amy", "%x", "%z"},
+			expectedIDs:  []int{1, 3},
+		},
+		"and between tokens": {
+			filter: map[string]any{
+				"name": "j x",
+			},
+			options:      []Option{Tokenised(), WithTokenColumns("name", "other")},
+			expectedSQL:  "SELECT * FROM `object_ps` WHERE (CAST(`object_ps`.`name` as varchar) LIKE ? OR CAST(`object_ps`.`other` as varchar) LIKE ?) AND (CAST(`object_ps`.`name` as varchar) LIKE ? OR CAST(`object_ps`.`other` as varchar) LIKE ?)",
+			expectedVars: []any{"%j%", "%j%", "%x%", "%x%"},
+			expectedIDs:  []int{1},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectP{})
+
+			existing := []ObjectP{
+				{ID: 1, Name: "jessica", Other: "x"},
+				{ID: 2, Name: "john", Other: "y"},
+				{ID: 3, Name: "amy", Other: "z"},
+			}
+			require.NoError(t, db.Create(&existing).Error)
+
+			// Act
+			err := db.Use(New(testData.options...))
+
+			// Assert
+			require.NoError(t, err)
+
+			var actual []ObjectP
+			query := db.Session(&gorm.Session{DryRun: true}).Where(testData.filter).Find(&actual)
+			require.NoError(t, query.Error)
+
+			assert.Equal(t, testData.expectedSQL, query.Statement.SQL.String())
+			assert.Equal(t, testData.expectedVars, query.Statement.Vars)
+
+			require.NoError(t, db.Where(testData.filter).Order("id").Find(&actual).Error)
+
+			var actualIDs []int
+			for _, object := range actual {
+				actualIDs = append(actualIDs, object.ID)
+			}
+
+			assert.Equal(t, testData.expectedIDs, actualIDs)
+		})
+	}
+}
+
 func TestGormLike_Initialize_TriggersLikingCorrectlyWithConditionalTag(t *testing.T) {
 	t.Parallel()
 
@@ -661,3 +748,567 @@ func TestGormLike_Initialize_ProcessUnknownFields(t *testing.T) {
 		})
 	}
 }
+
+func TestGormLike_Initialize_TriggersLikingInHavingCorrectly(t *testing.T) {
+	t.Parallel()
+
+	type Product struct {
+		ID       int
+		Category string
+		Brand    string `gormlike:"false"`
+	}
+
+	type Result struct {
+		Category string
+		Total    int
+	}
+
+	tests := map[string]struct {
+		group    string
+		having   map[string]any
+		options  []Option
+		expected []Result
+	}{
+		"simple having": {
+			group:    "category",
+			having:   map[string]any{"category": "elec%"},
+			expected: []Result{{Category: "electronics", Total: 2}, {Category: "electricity", Total: 1}},
+		},
+		"multi-value having": {
+			group:    "category",
+			having:   map[string]any{"category": []string{"%ics", "toys"}},
+			expected: []Result{{Category: "electronics", Total: 2}, {Category: "toys", Total: 1}},
+		},
+		"having on disallowed field": {
+			group:    "brand",
+			having:   map[string]any{"brand": "%"},
+			expected: []Result{},
+		},
+		"having with setting only": {
+			group:    "category",
+			having:   map[string]any{"category": "elec%"},
+			options:  []Option{SettingOnly()},
+			expected: []Result{},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&Product{})
+
+			existing := []Product{
+				{ID: 1, Category: "electronics", Brand: "a"},
+				{ID: 2, Category: "electronics", Brand: "b"},
+				{ID: 3, Category: "electricity", Brand: "a"},
+				{ID: 4, Category: "toys", Brand: "c"},
+			}
+			require.NoError(t, db.Create(&existing).Error)
+
+			// Act
+			err := db.Use(New(testData.options...))
+
+			// Assert
+			require.NoError(t, err)
+
+			actual := []Result{}
+			err = db.Model(&Product{}).
+				Select(testData.group + " AS category, COUNT(*) AS total").
+				Group(testData.group).
+				Having(testData.having).
+				Order("total DESC, category DESC").
+				Scan(&actual).Error
+			require.NoError(t, err)
+
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
+
+func TestGormLike_Initialize_TriggersLikingInJoinsCorrectly(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		query    func(*gorm.DB) *gorm.DB
+		options  []Option
+		expected []int
+	}{
+		"join on association with conditions": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.InnerJoins("Company", db.Where(map[string]any{"name": "%BV"}))
+			},
+			expected: []int{1, 3},
+		},
+		"join on association with multi-value conditions": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.InnerJoins("Company", db.Where(map[string]any{"name": []string{"Init%", "Globex"}}))
+			},
+			expected: []int{2},
+		},
+		"join on association with conditions on disallowed field": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.InnerJoins("Company", db.Where(map[string]any{"secret": "%b%"}))
+			},
+			expected: []int{},
+		},
+		"join on association with conditions on field that is not tagged": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.InnerJoins("Company", db.Where(map[string]any{"name": "%BV"}))
+			},
+			options:  []Option{TaggedOnly()},
+			expected: []int{},
+		},
+		"join on association with an alias": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.InnerJoins("Company", db.Where(map[string]any{"name": "%BV"})).Where("company.name IS NOT NULL")
+			},
+			expected: []int{1, 3},
+		},
+		"join clause with conditions": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(clause.From{Joins: []clause.Join{{
+					Type:  clause.InnerJoin,
+					Table: clause.Table{Name: "companies"},
+					ON: clause.Where{Exprs: []clause.Expression{
+						clause.Eq{Column: clause.Column{Table: "companies", Name: "id"}, Value: clause.Column{Table: clause.CurrentTable, Name: "company_id"}},
+						clause.Eq{Column: clause.Column{Table: "companies", Name: "name"}, Value: "%BV"},
+					}},
+				}}})
+			},
+			expected: []int{1, 3},
+		},
+		"join clause with conditions on disallowed field of aliased table": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(clause.From{Joins: []clause.Join{{
+					Type:  clause.InnerJoin,
+					Table: clause.Table{Name: "companies", Alias: "c"},
+					ON: clause.Where{Exprs: []clause.Expression{
+						clause.Eq{Column: clause.Column{Table: "c", Name: "id"}, Value: clause.Column{Table: clause.CurrentTable, Name: "company_id"}},
+						clause.Eq{Column: clause.Column{Table: "c", Name: "secret"}, Value: "%b%"},
+					}},
+				}}})
+			},
+			expected: []int{},
+		},
+		"where on joined association": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.InnerJoins("Company").Where(clause.Eq{Column: clause.Column{Table: "Company", Name: "name"}, Value: "%BV"})
+			},
+			expected: []int{1, 3},
+		},
+		"map with column of joined association": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.InnerJoins("Company").Where(map[string]any{"Company.name": "%BV"})
+			},
+			expected: []int{1, 3},
+		},
+		"map with column qualified with the table": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.InnerJoins("Company").Where(map[string]any{"employees.name": "J%"})
+			},
+			expected: []int{1, 2},
+		},
+		"where on disallowed field of joined association": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.InnerJoins("Company").Where(clause.Eq{Column: clause.Column{Table: "Company", Name: "secret"}, Value: "%b%"})
+			},
+			expected: []int{},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&Company{}, &Address{}, &Order{}, &Skill{}, &Comment{}, &Employee{})
+
+			acme := &Company{ID: 1, Name: "Acme BV", Secret: "abc"}
+			existing := []Employee{
+				{ID: 1, Name: "John", Company: acme},
+				{ID: 2, Name: "Jane", Company: &Company{ID: 2, Name: "Initech", Secret: "abc"}},
+				{ID: 3, Name: "Amy", Company: acme},
+			}
+			require.NoError(t, db.Create(&existing).Error)
+
+			// Act
+			err := db.Use(New(testData.options...))
+
+			// Assert
+			require.NoError(t, err)
+
+			var employees []Employee
+			err = testData.query(db).Order("employees.id").Find(&employees).Error
+			require.NoError(t, err)
+
+			actual := []int{}
+			for _, employee := range employees {
+				actual = append(actual, employee.ID)
+			}
+
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
+
+func TestGormLike_Initialize_TriggersLikingInSubqueriesCorrectly(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		query       func(*gorm.DB) *gorm.DB
+		options     []Option
+		expected    []int
+		expectedErr error
+	}{
+		"subquery in where": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where("id IN (?)", db.Model(&Order{}).Where(map[string]any{"reference": "INV%"}).Select("employee_id"))
+			},
+			expected: []int{1, 2},
+		},
+		"subquery with multi-value condition": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where("id IN (?)", db.Model(&Order{}).Where(map[string]any{"reference": []string{"QUO%", "none"}}).Select("employee_id"))
+			},
+			expected: []int{1},
+		},
+		"nested subqueries": {
+			query: func(db *gorm.DB) *gorm.DB {
+				companies := db.Model(&Company{}).Where(map[string]any{"name": "%BV"}).Select("id")
+				employees := db.Model(&Employee{}).Where("company_id IN (?)", companies).Select("id")
+
+				return db.Where("id IN (?)", employees)
+			},
+			expected: []int{1, 3},
+		},
+		"subquery in named expression": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where("id IN (@orders)", map[string]any{"orders": db.Model(&Order{}).Where(map[string]any{"reference": "INV%"}).Select("employee_id")})
+			},
+			expected: []int{1, 2},
+		},
+		"subquery as table": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Table("(?) AS employees", db.Model(&Employee{}).Where(map[string]any{"name": "J%"}))
+			},
+			expected: []int{1, 2},
+		},
+		"subquery uses its own tags": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where("company_id IN (?)", db.Model(&Company{}).Where(map[string]any{"secret": "%b%"}).Select("id"))
+			},
+			expected: []int{},
+		},
+		"subquery in strict mode": {
+			options: []Option{Strict()},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where("company_id IN (?)", db.Model(&Company{}).Where(map[string]any{"secret": "%x"}).Select("id"))
+			},
+			expectedErr: ErrFieldNotLikeable,
+		},
+		"nested subqueries in strict mode": {
+			options: []Option{Strict()},
+			query: func(db *gorm.DB) *gorm.DB {
+				companies := db.Model(&Company{}).Where(map[string]any{"secret": "%x"}).Select("id")
+				employees := db.Model(&Employee{}).Where("company_id IN (?)", companies).Select("id")
+
+				return db.Where("id IN (?)", employees)
+			},
+			expectedErr: ErrFieldNotLikeable,
+		},
+		"subquery in named expression in strict mode": {
+			options: []Option{Strict()},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where("company_id IN (@companies)", map[string]any{"companies": db.Model(&Company{}).Where(map[string]any{"secret": "%x"}).Select("id")})
+			},
+			expectedErr: ErrFieldNotLikeable,
+		},
+		"subquery as table in strict mode": {
+			options: []Option{Strict()},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Table("(?) AS employees", db.Model(&Employee{}).Where(map[string]any{"nickname": "J%"}))
+			},
+			expectedErr: ErrUnknownField,
+		},
+		"valid subquery in strict mode": {
+			options: []Option{Strict()},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where("id IN (?)", db.Model(&Order{}).Where(map[string]any{"reference": "INV%"}).Select("employee_id"))
+			},
+			expected: []int{1, 2},
+		},
+		"subquery uses its own setting": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where("id IN (?)", db.Set(tagName, false).Model(&Order{}).Where(map[string]any{"reference": "INV%"}).Select("employee_id"))
+			},
+			expected: []int{},
+		},
+		"subquery with setting only": {
+			options: []Option{SettingOnly()},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Where("id IN (?)", db.Set(tagName, true).Model(&Order{}).Where(map[string]any{"reference": "INV%"}).Select("employee_id"))
+			},
+			expected: []int{1, 2},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&Company{}, &Address{}, &Order{}, &Skill{}, &Comment{}, &Employee{})
+
+			acme := &Company{ID: 1, Name: "Acme BV", Secret: "abc"}
+			existing := []Employee{
+				{ID: 1, Name: "John", Company: acme, Orders: []Order{{ID: 1, Reference: "INV-1"}, {ID: 2, Reference: "QUO-1"}}},
+				{ID: 2, Name: "Jane", Company: &Company{ID: 2, Name: "Initech", Secret: "def"}, Orders: []Order{{ID: 3, Reference: "INV-2"}}},
+				{ID: 3, Name: "Amy", Company: acme},
+			}
+			require.NoError(t, db.Create(&existing).Error)
+
+			// Act
+			err := db.Use(New(testData.options...))
+
+			// Assert
+			require.NoError(t, err)
+
+			var employees []Employee
+			err = testData.query(db).Order("id").Find(&employees).Error
+
+			if testData.expectedErr != nil {
+				require.ErrorIs(t, err, testData.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			actual := []int{}
+			for _, employee := range employees {
+				actual = append(actual, employee.ID)
+			}
+
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
+
+func TestGormLike_Initialize_GeneratesDialectSpecificCasts(t *testing.T) {
+	t.Parallel()
+
+	type ObjectC struct {
+		Name string
+		Age  int
+	}
+
+	tests := map[string]struct {
+		dialect  string
+		expected string
+	}{
+		"postgres": {
+			dialect:  "postgres",
+			expected: "SELECT * FROM `object_cs` WHERE CAST(`object_cs`.`age` as varchar) LIKE ? AND CAST(`object_cs`.`name` as varchar) LIKE ?",
+		},
+		"mysql leaves strings alone": {
+			dialect:  "mysql",
+			expected: "SELECT * FROM `object_cs` WHERE CAST(`object_cs`.`age` as char) LIKE ? AND `object_cs`.`name` LIKE ?",
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			db.Dialector = namedDialector{Dialector: db.Dialector, name: testData.dialect}
+
+			// Act
+			err := db.Use(New())
+
+			// Assert
+			require.NoError(t, err)
+
+			var actual []ObjectC
+			query := db.Session(&gorm.Session{DryRun: true}).Where(map[string]any{"name": "jo%", "age": "1%"}).Find(&actual)
+			require.NoError(t, query.Error)
+
+			assert.Equal(t, testData.expected, query.Statement.SQL.String())
+		})
+	}
+}
+
+func TestParseTag_ReturnsExpectedSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		tag      string
+		expected fieldTag
+	}{
+		"empty":           {tag: "", expected: fieldTag{}},
+		"true":            {tag: "true", expected: fieldTag{value: "true"}},
+		"false":           {tag: "false", expected: fieldTag{value: "false"}},
+		"match":           {tag: "true;match:prefix", expected: fieldTag{value: "true", match: "prefix"}},
+		"spaces and case": {tag: "true; MATCH: Prefix", expected: fieldTag{value: "true", match: "prefix"}},
+		"only match":      {tag: "match:prefix", expected: fieldTag{match: "prefix"}},
+		"unknown setting": {tag: "true;other:value", expected: fieldTag{value: "true"}},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := parseTag(testData.tag)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+// namedDialector overrides the name of a dialector, used to verify the queries generated for other databases
+type namedDialector struct {
+	gorm.Dialector
+
+	name string
+}
+
+func (n namedDialector) Name() string {
+	return n.name
+}
+
+type ObjectX struct {
+	ID       int
+	Name     string
+	Metadata map[string]any `gorm:"serializer:json"`
+}
+
+func TestGormLike_Initialize_OnlyConvertsKnownColumns(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options []Option
+		query   func(*gorm.DB) *gorm.DB
+
+		expected    string
+		expectedErr error
+	}{
+		"field of the model": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&ObjectX{}).Where(map[string]any{"name": "j%"})
+			},
+			expected: "SELECT * FROM `object_xes` WHERE CAST(`object_xes`.`name` as varchar) LIKE ?",
+		},
+		"column that isn't a field": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&ObjectX{}).Where(map[string]any{"nickname": "j%"})
+			},
+			expected: "SELECT * FROM `object_xes` WHERE `object_xes`.`nickname` = ?",
+		},
+		"column that isn't a field in strict mode": {
+			options: []Option{Strict()},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&ObjectX{}).Where(map[string]any{"name` LIKE ? OR 1=1 --": "j%"})
+			},
+			expectedErr: ErrUnknownField,
+		},
+		"query without model": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Table("object_xes").Where(map[string]any{"name": "j%"})
+			},
+			expected: "SELECT * FROM `object_xes` WHERE `object_xes`.`name` = ?",
+		},
+		"allowed column of query without model": {
+			options: []Option{AllowColumns("name")},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Table("object_xes").Where(map[string]any{"name": "j%"})
+			},
+			expected: "SELECT * FROM `object_xes` WHERE CAST(`object_xes`.`name` as varchar) LIKE ?",
+		},
+		"field qualified with the table": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&ObjectX{}).Where(map[string]any{"object_xes.name": "j%"})
+			},
+			expected: "SELECT * FROM `object_xes` WHERE CAST(`object_xes`.`name` as varchar) LIKE ?",
+		},
+		"field qualified with another table": {
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&ObjectX{}).Where(map[string]any{"users.name": "j%"})
+			},
+			expected: "SELECT * FROM `object_xes` WHERE `object_xes`.`users`.`name` = ?",
+		},
+		"allowed column that isn't a field": {
+			options: []Option{AllowColumns("nickname")},
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&ObjectX{}).Where(map[string]any{"nickname": "j%"})
+			},
+			expected: "SELECT * FROM `object_xes` WHERE CAST(`object_xes`.`nickname` as varchar) LIKE ?",
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+			// Act
+			err := db.Use(New(testData.options...))
+
+			// Assert
+			require.NoError(t, err)
+
+			query := testData.query(db.Session(&gorm.Session{DryRun: true})).Find(&[]map[string]any{})
+
+			if testData.expectedErr != nil {
+				require.ErrorIs(t, query.Error, testData.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, query.Error)
+			assert.Equal(t, testData.expected, query.Statement.SQL.String())
+		})
+	}
+}
+
+// knownColumnPattern matches the fields of ObjectX, optionally prefixed with its table, and the paths in its JSON column
+var knownColumnPattern = regexp.MustCompile(`^((object_xes\.)?(id|name)|metadata(\.\w+)+)$`)
+
+func FuzzRewrite_KeepsInputOutOfQuery(f *testing.F) {
+	f.Add("name", "j%")
+	f.Add("metadata.customer.name", "%bv")
+	f.Add("name` LIKE ? OR 1=1 --", "%")
+	f.Add("metadata.customer'); DROP TABLE users; --", "%")
+	f.Add("name", "%') OR 1=1 --")
+	f.Add("unknown", "a%")
+	f.Add("object_xes.name", "j%")
+	f.Add("object_xes.name` OR 1=1 --", "j%")
+
+	db := newMemoryDatabase(f)
+
+	statement := &gorm.Statement{DB: db, Model: &ObjectX{}}
+	require.NoError(f, statement.Parse(statement.Model))
+
+	f.Fuzz(func(t *testing.T, key, value string) {
+		column := clause.Column{Table: clause.CurrentTable, Name: key}
+
+		result, err := Rewrite(statement, []clause.Expression{clause.Eq{Column: column, Value: value}})
+		require.NoError(t, err)
+
+		converted, ok := result[0].(clause.Expr)
+		if !ok {
+			return
+		}
+
+		// Only known columns end up in the query, and the value only ends up in the variables
+		assert.Regexp(t, knownColumnPattern, key)
+		assert.Equal(t, []any{value}, converted.Vars)
+
+		reference, err := Rewrite(statement, []clause.Expression{clause.Eq{Column: column, Value: "%"}})
+		require.NoError(t, err)
+		assert.Equal(t, reference[0].(clause.Expr).SQL, converted.SQL)
+	})
+}
@@ -0,0 +1,28 @@
+package gormlike
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Rewrite converts the expressions in the same way the plugin would for a query on the statement, without the
+// plugin having to be registered. The model of the statement is parsed if that hasn't happened yet, and the given
+// expressions are left untouched. As the caller decides to convert the expressions, the `gormlike` setting is ignored.
+func Rewrite(stmt *gorm.Statement, exprs []clause.Expression, opts ...Option) ([]clause.Expression, error) {
+	if stmt == nil || stmt.DB == nil {
+		return nil, gorm.ErrInvalidDB
+	}
+
+	if stmt.Schema == nil && stmt.Model != nil {
+		if err := stmt.Parse(stmt.Model); err != nil {
+			return nil, err
+		}
+	}
+
+	// The conversion works on a *gorm.DB, so we wrap the statement in a new one to leave stmt.DB alone
+	db := &gorm.DB{Config: stmt.DB.Config, Statement: stmt}
+
+	result := newGormLike(opts...).replaceExpressions(db, source{schema: stmt.Schema, table: clause.CurrentTable}, exprs)
+
+	return result, db.Error
+}
@@ -0,0 +1,156 @@
+package gormlike
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestRewrite_ConvertsExpressions(t *testing.T) {
+	t.Parallel()
+
+	type ObjectG struct {
+		ID    int
+		Name  string
+		Other string `gormlike:"false"`
+	}
+
+	john := ObjectG{ID: 1, Name: "john", Other: "abc"}
+	jane := ObjectG{ID: 2, Name: "jane", Other: "def"}
+	amy := ObjectG{ID: 3, Name: "amy", Other: "ghi"}
+
+	tests := map[string]struct {
+		exprs    []clause.Expression
+		options  []Option
+		expected []ObjectG
+	}{
+		"simple expression": {
+			exprs: []clause.Expression{
+				clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "name"}, Value: "j%"},
+			},
+			expected: []ObjectG{john, jane},
+		},
+		"nested expressions": {
+			exprs: []clause.Expression{
+				clause.Or(
+					clause.Eq{Column: clause.Column{Name: "name"}, Value: "%hn"},
+					clause.IN{Column: clause.Column{Name: "name"}, Values: []any{"a%", "nobody"}},
+				),
+			},
+			expected: []ObjectG{john, amy},
+		},
+		"where clause of another statement": {
+			exprs: []clause.Expression{
+				clause.Where{Exprs: []clause.Expression{
+					clause.Expr{SQL: "LOWER(name) = LOWER(?)", Vars: []any{"J%"}},
+				}},
+			},
+			expected: []ObjectG{john, jane},
+		},
+		"with options": {
+			exprs: []clause.Expression{
+				clause.Eq{Column: clause.Column{Name: "name"}, Value: "j🍌"},
+			},
+			options:  []Option{WithCharacter("🍌")},
+			expected: []ObjectG{john, jane},
+		},
+		"respects tags": {
+			exprs: []clause.Expression{
+				clause.Eq{Column: clause.Column{Name: "other"}, Value: "%"},
+			},
+			expected: []ObjectG{},
+		},
+		"respects tagged only": {
+			exprs: []clause.Expression{
+				clause.Eq{Column: clause.Column{Name: "name"}, Value: "j%"},
+			},
+			options:  []Option{TaggedOnly()},
+			expected: []ObjectG{},
+		},
+		"ignores setting only": {
+			exprs: []clause.Expression{
+				clause.Eq{Column: clause.Column{Name: "name"}, Value: "j%"},
+			},
+			options:  []Option{SettingOnly()},
+			expected: []ObjectG{john, jane},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectG{})
+			require.NoError(t, db.Create([]ObjectG{john, jane, amy}).Error)
+
+			stmt := &gorm.Statement{DB: db, Model: &ObjectG{}}
+			original := append([]clause.Expression{}, testData.exprs...)
+
+			// Act
+			result, err := Rewrite(stmt, testData.exprs, testData.options...)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, original, testData.exprs)
+
+			var actual []ObjectG
+			err = db.Clauses(clause.Where{Exprs: result}).Find(&actual).Error
+			require.NoError(t, err)
+
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
+
+func TestRewrite_ReturnsErrorOnInvalidStatement(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		stmt *gorm.Statement
+	}{
+		"no statement": {
+			stmt: nil,
+		},
+		"no database": {
+			stmt: &gorm.Statement{},
+		},
+		"invalid model": {
+			stmt: &gorm.Statement{DB: gormtestutil.NewMemoryDatabase(t), Model: 1},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result, err := Rewrite(testData.stmt, []clause.Expression{clause.Eq{Column: clause.Column{Name: "name"}, Value: "j%"}})
+
+			// Assert
+			require.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestRewrite_ReturnsErrorsInStrictMode(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	type ObjectG struct {
+		Name string `gormlike:"false"`
+	}
+
+	stmt := &gorm.Statement{DB: gormtestutil.NewMemoryDatabase(t), Model: &ObjectG{}}
+	exprs := []clause.Expression{clause.Eq{Column: clause.Column{Name: "name"}, Value: "j%"}}
+
+	// Act
+	_, err := Rewrite(stmt, exprs, Strict())
+
+	// Assert
+	require.ErrorIs(t, err, ErrFieldNotLikeable)
+	assert.ErrorContains(t, err, "name")
+}
@@ -0,0 +1,123 @@
+package gormlike
+
+import (
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// tokeniseKey returns the setting that makes the plugin with the given name tokenise the conditions of a query
+func tokeniseKey(name string) string {
+	return name + ":tokenise"
+}
+
+// tokenises returns whether a condition on the column is tokenised. Tokenised() only applies to the columns given to
+// WithTokenColumns() and to queries with the tokenise setting, so other lookups on the same columns stay exact.
+func (d *gormLike) tokenises(db *gorm.DB, column any) bool {
+	if !d.tokenised {
+		return false
+	}
+
+	if columnValue, ok := column.(clause.Column); ok {
+		if _, configured := d.tokenColumnMap[columnValue.Name]; configured {
+			return true
+		}
+	}
+
+	setting, _ := db.Get(tokeniseKey(d.name))
+	settingValue, _ := setting.(bool)
+
+	return settingValue
+}
+
+// tokenise splits a value on whitespace, "quoted phrases" are kept intact as a single token
+func tokenise(value string) []string {
+	var tokens []string
+
+	var current strings.Builder
+
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	var inQuotes bool
+
+	for _, character := range value {
+		switch {
+		case character == '"':
+			flush()
+
+			inQuotes = !inQuotes
+		case unicode.IsSpace(character) && !inQuotes:
+			flush()
+		default:
+			current.WriteRune(character)
+		}
+	}
+
+	flush()
+
+	return tokens
+}
+
+// tokenTargets returns the target itself and any likeable targets that were configured using WithTokenColumns
+func (d *gormLike) tokenTargets(db *gorm.DB, src source, column clause.Column, columnTarget target, patterns []string) []target {
+	result := []target{columnTarget}
+
+	for _, extraColumn := range d.tokenColumnMap[column.Name] {
+		extraTarget, ok := d.resolveTarget(db, src, clause.Column{Table: column.Table, Name: extraColumn})
+		if !ok || !d.isLikeable(extraTarget.policy().value) || !extraTarget.policy().allows(patterns...) {
+			continue
+		}
+
+		result = append(result, extraTarget)
+	}
+
+	return result
+}
+
+// tokenPatterns splits the value into tokens and turns them into %token% patterns
+func (d *gormLike) tokenPatterns(value string) []string {
+	tokens := tokenise(value)
+
+	for index, token := range tokens {
+		token = d.replaceWildcards(token)
+
+		// Tokens that already contain a wildcard are left alone, the user knows what they're doing
+		if !strings.Contains(token, "%") {
+			token = "%" + token + "%"
+		}
+
+		tokens[index] = token
+	}
+
+	return tokens
+}
+
+// tokenExpression turns the patterns into a condition where every pattern must be found in at least one of the
+// targets, e.g. (name LIKE %john% OR city LIKE %john%) AND (name LIKE %amsterdam% OR city LIKE %amsterdam%).
+// caseInsensitive makes all targets compare case-insensitively, regardless of their settings.
+func (d *gormLike) tokenExpression(db *gorm.DB, src source, column clause.Column, columnTarget target, patterns []string, caseInsensitive bool) clause.Expression {
+	targets := d.tokenTargets(db, src, column, columnTarget, patterns)
+
+	conditions := make([]clause.Expression, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		d.converted(db, src, column, columnTarget.condition(columnTarget.comparison(caseInsensitive)), pattern)
+
+		tokenConditions := make([]clause.Expression, 0, len(targets))
+
+		for _, tokenTarget := range targets {
+			tokenConditions = append(tokenConditions, clause.Expr{SQL: tokenTarget.condition(tokenTarget.comparison(caseInsensitive)), Vars: []any{pattern}})
+		}
+
+		conditions = append(conditions, orExpression(tokenConditions))
+	}
+
+	return clause.And(conditions...)
+}
@@ -0,0 +1,226 @@
+package gormlike
+
+import (
+	"testing"
+
+	"github.com/ing-bank/gormtestutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestTokenise_ReturnsExpectedTokens(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input    string
+		expected []string
+	}{
+		"empty": {
+			input:    "",
+			expected: nil,
+		},
+		"only whitespace": {
+			input:    "  \t\n ",
+			expected: nil,
+		},
+		"single word": {
+			input:    "john",
+			expected: []string{"john"},
+		},
+		"multiple words": {
+			input:    "john  amsterdam\tnetherlands",
+			expected: []string{"john", "amsterdam", "netherlands"},
+		},
+		"quoted phrase": {
+			input:    `"new york" john`,
+			expected: []string{"new york", "john"},
+		},
+		"quoted phrase attached to a word": {
+			input:    `john"new york"`,
+			expected: []string{"john", "new york"},
+		},
+		"unterminated quote": {
+			input:    `john "new york`,
+			expected: []string{"john", "new york"},
+		},
+		"empty quotes": {
+			input:    `john ""`,
+			expected: []string{"john"},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			result := tokenise(testData.input)
+
+			// Assert
+			assert.Equal(t, testData.expected, result)
+		})
+	}
+}
+
+func TestGormLike_Initialize_TriggersTokenisedLikingCorrectly(t *testing.T) {
+	t.Parallel()
+
+	type ObjectC struct {
+		Name    string
+		City    string
+		Country string `gormlike:"false"`
+		Age     int
+	}
+
+	john := ObjectC{Name: "John Doe", City: "Amsterdam", Country: "Netherlands", Age: 25}
+	jane := ObjectC{Name: "Jane Doe", City: "New York", Country: "United States", Age: 30}
+	amy := ObjectC{Name: "Amy", City: "Amsterdam", Country: "Netherlands", Age: 30}
+
+	tests := map[string]struct {
+		filter         map[string]any
+		options        []Option
+		withoutSetting bool
+		existing       []ObjectC
+		expected       []ObjectC
+	}{
+		"single word": {
+			filter:   map[string]any{"name": "doe"},
+			existing: []ObjectC{john, jane, amy},
+			expected: []ObjectC{john, jane},
+		},
+		"multiple words must all match": {
+			filter:   map[string]any{"name": "doe jo"},
+			existing: []ObjectC{john, jane, amy},
+			expected: []ObjectC{john},
+		},
+		"words in a different order": {
+			filter:   map[string]any{"name": "doe john"},
+			existing: []ObjectC{john, jane, amy},
+			expected: []ObjectC{john},
+		},
+		"quoted phrase is kept intact": {
+			filter:   map[string]any{"name": `"doe jane"`},
+			existing: []ObjectC{john, jane, amy},
+			expected: []ObjectC{},
+		},
+		"words with wildcards are left alone": {
+			filter:   map[string]any{"name": "j% doe"},
+			existing: []ObjectC{john, jane, amy},
+			expected: []ObjectC{john, jane},
+		},
+		"words with custom character": {
+			filter:   map[string]any{"name": "jo🍌 doe"},
+			options:  []Option{WithCharacter("🍌")},
+			existing: []ObjectC{john, jane, amy},
+			expected: []ObjectC{john},
+		},
+		"combined with other filters": {
+			filter:   map[string]any{"name": "doe", "age": 30},
+			existing: []ObjectC{john, jane, amy},
+			expected: []ObjectC{jane},
+		},
+		"exact lookup without setting": {
+			filter:         map[string]any{"name": "doe"},
+			withoutSetting: true,
+			existing:       []ObjectC{john, jane, amy},
+			expected:       []ObjectC{},
+		},
+		"configured column without setting": {
+			filter:         map[string]any{"name": "doe"},
+			options:        []Option{WithTokenColumns("name")},
+			withoutSetting: true,
+			existing:       []ObjectC{john, jane, amy},
+			expected:       []ObjectC{john, jane},
+		},
+		"words may match in other columns": {
+			filter:   map[string]any{"name": "doe amsterdam"},
+			options:  []Option{WithTokenColumns("name", "city")},
+			existing: []ObjectC{john, jane, amy},
+			expected: []ObjectC{john},
+		},
+		"phrases may match in other columns": {
+			filter:   map[string]any{"name": `"new york"`},
+			options:  []Option{WithTokenColumns("name", "city")},
+			existing: []ObjectC{john, jane, amy},
+			expected: []ObjectC{jane},
+		},
+		"other columns that aren't likeable are ignored": {
+			filter:   map[string]any{"name": "doe netherlands"},
+			options:  []Option{WithTokenColumns("name", "country")},
+			existing: []ObjectC{john, jane, amy},
+			expected: []ObjectC{},
+		},
+		"other columns of other filters are ignored": {
+			filter:   map[string]any{"name": "amsterdam"},
+			options:  []Option{WithTokenColumns("city", "name")},
+			existing: []ObjectC{john, jane, amy},
+			expected: []ObjectC{},
+		},
+		"non-likeable fields aren't tokenised": {
+			filter:   map[string]any{"country": "netherlands"},
+			existing: []ObjectC{john, jane, amy},
+			expected: []ObjectC{},
+		},
+		"multi-value filters aren't tokenised": {
+			filter:   map[string]any{"name": []string{"doe", "Amy"}},
+			existing: []ObjectC{john, jane, amy},
+			expected: []ObjectC{amy},
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+			_ = db.AutoMigrate(&ObjectC{})
+			plugin := New(append([]Option{Tokenised()}, testData.options...)...)
+
+			if err := db.CreateInBatches(testData.existing, 10).Error; err != nil {
+				t.Error(err)
+				t.FailNow()
+			}
+
+			// Act
+			err := db.Use(plugin)
+
+			// Assert
+			require.NoError(t, err)
+
+			query := db
+			if !testData.withoutSetting {
+				query = query.Set("gormlike:tokenise", true)
+			}
+
+			var actual []ObjectC
+			err = query.Where(testData.filter).Find(&actual).Error
+			require.NoError(t, err)
+
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
+
+func TestGormLike_Initialize_TokenisedLeavesExactLookupsAlone(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	type ObjectC struct {
+		ID   int
+		Name string
+	}
+
+	db := gormtestutil.NewMemoryDatabase(t, gormtestutil.WithName(t.Name()))
+
+	// Act
+	err := db.Use(New(Tokenised()))
+
+	// Assert
+	require.NoError(t, err)
+
+	var actual ObjectC
+	query := db.Session(&gorm.Session{DryRun: true}).Where(&ObjectC{Name: "exact"}).First(&actual)
+
+	require.NoError(t, query.Error)
+	assert.Equal(t, "SELECT * FROM `object_cs` WHERE `object_cs`.`name` = ? ORDER BY `object_cs`.`id` LIMIT 1", query.Statement.SQL.String())
+	assert.Equal(t, []any{"exact"}, query.Statement.Vars)
+}